@@ -0,0 +1,111 @@
+package makercad
+
+import (
+	"fmt"
+
+	"github.com/marcuswu/makercad/mesh"
+	"github.com/marcuswu/makercad/sketcher"
+)
+
+// addEdge adds a line, circle or arc edge to bounds exactly. The wrapper cannot evaluate points along other curves.
+func addEdge(bounds *mesh.Bounds, edge *sketcher.Edge) error {
+	if edge.IsLine() {
+		bounds.AddPoint(meshPoint(edge.FirstVertex()))
+		bounds.AddPoint(meshPoint(edge.LastVertex()))
+		return nil
+	}
+	arc, err := edge.CircularArc()
+	if err != nil {
+		return fmt.Errorf("cannot bound edges other than lines, circles and arcs: %w", err)
+	}
+	vector := func(v sketcher.Vector) mesh.Vector { return mesh.Vector{X: v.X, Y: v.Y, Z: v.Z} }
+	bounds.AddArc(mesh.Arc{Center: vector(arc.Center), U: vector(arc.U), V: vector(arc.V), Radius: arc.Radius, Sweep: arc.Sweep})
+	return nil
+}
+
+// edgeBounds collects the exact extent of line, circle and arc edges
+func edgeBounds(edges sketcher.ListOfEdge) (*mesh.Bounds, error) {
+	bounds := &mesh.Bounds{}
+	for _, edge := range edges {
+		if err := addEdge(bounds, edge); err != nil {
+			return nil, err
+		}
+	}
+	return bounds, nil
+}
+
+// EdgesBoundingBox returns the axis-aligned box enclosing the edges, such as the edges selected for a fillet.
+// Only lines, circles and arcs can be bounded; use [Shape.BoundingBox] for shapes with other curves.
+func EdgesBoundingBox(edges sketcher.ListOfEdge) (mesh.BoundingBox, error) {
+	bounds, err := edgeBounds(edges)
+	if err != nil {
+		return mesh.BoundingBox{}, err
+	}
+	return bounds.AxisAligned()
+}
+
+// EdgesOrientedBoundingBox returns a box enclosing the edges aligned with their principal axes (see [mesh.Bounds.Oriented])
+func EdgesOrientedBoundingBox(edges sketcher.ListOfEdge) (mesh.BoundingBox, error) {
+	bounds, err := edgeBounds(edges)
+	if err != nil {
+		return mesh.BoundingBox{}, err
+	}
+	return bounds.Oriented()
+}
+
+// bounds collects the geometry of the shapes: the vertices of a fine triangulation and the exact extent of their lines,
+// circles and arcs. The wrapper has no bounding box algorithm, so surfaces and other curves are enclosed to within the
+// mesh precision.
+func (l ListOfShape) bounds() (*mesh.Bounds, error) {
+	triangles, err := triangulate(l, measureLinearDeflection, measureAngularDeflection)
+	if err != nil {
+		return nil, err
+	}
+	bounds := &mesh.Bounds{}
+	bounds.AddMesh(triangles)
+	for _, shape := range l {
+		for _, edge := range shape.Edges() {
+			// The triangulation already follows other curves
+			_ = addEdge(bounds, edge)
+		}
+	}
+	return bounds, nil
+}
+
+// BoundingBox returns the axis-aligned box enclosing all of the shapes, such as a set of parts to export together
+func (l ListOfShape) BoundingBox() (mesh.BoundingBox, error) {
+	bounds, err := l.bounds()
+	if err != nil {
+		return mesh.BoundingBox{}, err
+	}
+	return bounds.AxisAligned()
+}
+
+// OrientedBoundingBox returns a box enclosing all of the shapes aligned with their principal axes (see [mesh.Bounds.Oriented])
+func (l ListOfShape) OrientedBoundingBox() (mesh.BoundingBox, error) {
+	bounds, err := l.bounds()
+	if err != nil {
+		return mesh.BoundingBox{}, err
+	}
+	return bounds.Oriented()
+}
+
+// BoundingBox returns the axis-aligned box enclosing this shape
+func (s Shape) BoundingBox() (mesh.BoundingBox, error) {
+	return ListOfShape{s}.BoundingBox()
+}
+
+// OrientedBoundingBox returns a box enclosing this shape aligned with its principal axes
+func (s Shape) OrientedBoundingBox() (mesh.BoundingBox, error) {
+	return ListOfShape{s}.OrientedBoundingBox()
+}
+
+// BoundingBox returns the axis-aligned box enclosing this face
+func (f *Face) BoundingBox() (mesh.BoundingBox, error) {
+	return ListOfShape{*f.AsShape()}.BoundingBox()
+}
+
+// OrientedBoundingBox returns a box enclosing this face aligned with its principal axes
+func (f *Face) OrientedBoundingBox() (mesh.BoundingBox, error) {
+	return ListOfShape{*f.AsShape()}.OrientedBoundingBox()
+}
@@ -0,0 +1,173 @@
+package makercad
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/marcuswu/makercad/mesh"
+	"github.com/marcuswu/makercad/sketcher"
+
+	"github.com/marcuswu/gooccwrapper/brepbuilderapi"
+	"github.com/marcuswu/gooccwrapper/brepprimapi"
+	"github.com/marcuswu/gooccwrapper/geom"
+	"github.com/marcuswu/gooccwrapper/gp"
+)
+
+// ChamferDistances chamfers a straight convex edge between two planar faces of the target. The chamfer is set back from
+// the edge by distance on the reference face and by otherDistance on the other face meeting at the edge.
+// Curved edges, non-planar faces and edges whose ends do not meet planar faces perpendicular to them return an error;
+// [MakerCad.ChamferEdges] chamfers those by equal distances.
+func (*MakerCad) ChamferDistances(target Shape, edge *sketcher.Edge, face *Face, distance float64, otherDistance float64) (Shape, error) {
+	if distance <= 0 || otherDistance <= 0 {
+		return Shape{}, errors.New("chamfer distances must be positive")
+	}
+	corner, err := newChamferCorner(target, edge, face)
+	if err != nil {
+		return Shape{}, err
+	}
+	return corner.cut(target, distance, otherDistance)
+}
+
+// ChamferDistanceAngle chamfers a straight convex edge between two planar faces of the target. The chamfer is set back
+// from the edge by distance on the reference face and meets that face at the angle in radians. The edge must be one
+// [MakerCad.ChamferDistances] accepts.
+func (*MakerCad) ChamferDistanceAngle(target Shape, edge *sketcher.Edge, face *Face, distance float64, angle float64) (Shape, error) {
+	if distance <= 0 {
+		return Shape{}, errors.New("chamfer distance must be positive")
+	}
+	corner, err := newChamferCorner(target, edge, face)
+	if err != nil {
+		return Shape{}, err
+	}
+	// The chamfer and the faces form a triangle with the angle between the faces at the edge, so the law of sines gives
+	// the distance on the other face
+	if angle <= 0 || corner.angle+angle >= math.Pi {
+		return Shape{}, fmt.Errorf("chamfer angle must be between 0 and %f radians for this edge", math.Pi-corner.angle)
+	}
+	return corner.cut(target, distance, distance*math.Sin(angle)/math.Sin(corner.angle+angle))
+}
+
+// chamferCorner is a straight convex edge between two planar faces
+type chamferCorner struct {
+	start mesh.Vector
+	end   mesh.Vector
+	// reference and other are the unit directions from the edge into the reference face and the other face
+	reference mesh.Vector
+	other     mesh.Vector
+	// angle is the angle in radians between the faces inside the shape
+	angle float64
+}
+
+// newChamferCorner measures the edge for a chamfer. The chamfer is cut by a prism running past both ends of the edge, so
+// each end must meet faces which are planar and perpendicular to the edge with the shape behind them, such as the ends of
+// a box edge. Other ends, like the sloped end of a wedge or a wall rising from the end of the edge, are rejected because
+// the prism would leave or cut too much there.
+func newChamferCorner(target Shape, edge *sketcher.Edge, face *Face) (chamferCorner, error) {
+	if !edge.IsLine() {
+		return chamferCorner{}, errors.New("chamfers by two distances or a distance and an angle require a straight edge")
+	}
+	t := target.topology()
+	id := t.edges.find(edge)
+	if id < 0 || len(t.edgeFaces[id]) != 2 {
+		return chamferCorner{}, errors.New("chamfer edge must join two faces of the shape")
+	}
+	adjacent := slices.Clone(t.edgeFaces[id])
+	faces := t.faces
+	if faces[adjacent[1]].isSame(face) {
+		adjacent[0], adjacent[1] = adjacent[1], adjacent[0]
+	} else if !faces[adjacent[0]].isSame(face) {
+		return chamferCorner{}, errors.New("chamfer reference face does not meet the edge")
+	}
+
+	var normals, inward [2]mesh.Vector
+	for i, index := range adjacent {
+		if !faces[index].IsPlanar() {
+			return chamferCorner{}, errors.New("chamfers by two distances or a distance and an angle require planar faces")
+		}
+		chord, ok := orientedChord(faces[index], edge)
+		if !ok {
+			return chamferCorner{}, errors.New("chamfer edge has no length")
+		}
+		normals[i] = faceNormal(faces[index])
+		inward[i] = normals[i].Cross(chord)
+	}
+	if inward[0].Dot(normals[1]) >= 0 {
+		return chamferCorner{}, errors.New("chamfers by two distances or a distance and an angle require a convex edge")
+	}
+
+	start, end := meshPoint(edge.FirstVertex()), meshPoint(edge.LastVertex())
+	direction := end.Sub(start).Normalized()
+	vertices := edge.Vertexes()
+	for i, vertex := range []*sketcher.Vertex{vertices[0], vertices[len(vertices)-1]} {
+		// The faces at the start must face back along the edge and those at the end forward
+		outward := direction.Scale(float64(2*i - 1))
+		for _, f := range endFaces(t, vertex, adjacent) {
+			if !faces[f].IsPlanar() || faceNormal(faces[f]).Dot(outward) < 1-namingTolerance {
+				return chamferCorner{}, errors.New("chamfers by two distances or a distance and an angle require an edge ending at planar faces perpendicular to it")
+			}
+		}
+	}
+
+	return chamferCorner{
+		start:     start,
+		end:       end,
+		reference: inward[0],
+		other:     inward[1],
+		angle:     math.Acos(max(-1, min(1, inward[0].Dot(inward[1])))),
+	}, nil
+}
+
+// endFaces returns the indexes of the faces meeting at the vertex other than the faces along the edge
+func endFaces(t *topology, vertex *sketcher.Vertex, along []int) []int {
+	id := t.vertices.find(vertex)
+	if id < 0 {
+		return nil
+	}
+	faces := make([]int, 0)
+	for _, e := range t.vertexEdges[id] {
+		for _, f := range t.edgeFaces[e] {
+			if !slices.Contains(along, f) && !slices.Contains(faces, f) {
+				faces = append(faces, f)
+			}
+		}
+	}
+	return faces
+}
+
+// faceNormal returns the normal of a planar face as a mesh vector
+func faceNormal(face *Face) mesh.Vector {
+	normal := face.Normal()
+	return mesh.Vector{X: normal.X(), Y: normal.Y(), Z: normal.Z()}
+}
+
+// cut removes the prism between the edge and a chamfer set back by distance on the reference face and otherDistance on
+// the other face. The prism reaches past both faces and both ends of the edge so the cut does not depend on coincident
+// faces.
+func (c chamferCorner) cut(target Shape, distance float64, otherDistance float64) (Shape, error) {
+	margin := 0.1 * min(distance, otherDistance)
+	direction := c.end.Sub(c.start).Normalized()
+	start := c.start.Sub(direction.Scale(margin))
+	a := start.Add(c.reference.Scale(distance))
+	b := start.Add(c.other.Scale(otherDistance))
+	along := b.Sub(a).Normalized().Scale(margin)
+	outside := c.reference.Add(c.other).Normalized().Scale(-margin)
+	outline := []mesh.Vector{a.Sub(along), b.Add(along), start.Add(outside)}
+
+	wire := brepbuilderapi.NewMakeWire()
+	for i, from := range outline {
+		to := outline[(i+1)%len(outline)]
+		segment := geom.MakeSegment(gp.NewPnt(from.X, from.Y, from.Z), gp.NewPnt(to.X, to.Y, to.Z))
+		wire.AddEdge(brepbuilderapi.NewMakeEdge(segment).ToTopoDSEdge())
+	}
+	section := brepbuilderapi.NewMakeFace(wire.ToTopoDSWire()).ToTopoDSFace()
+	length := c.end.Sub(c.start).Add(direction.Scale(2 * margin))
+	prism := Shape{brepprimapi.NewMakePrism(section, gp.NewVec(length.X, length.Y, length.Z)).Shape()}
+
+	operation, err := target.Remove(ListOfShape{prism})
+	if err != nil {
+		return Shape{}, err
+	}
+	return operation.Shape(), nil
+}
@@ -0,0 +1,67 @@
+package makercad
+
+import (
+	"math"
+	"testing"
+
+	"github.com/marcuswu/makercad/mesh"
+	"github.com/marcuswu/makercad/sketcher"
+)
+
+// edgeAt returns the edge of the shape with its midpoint at the point
+func edgeAt(t *testing.T, shape Shape, midpoint mesh.Vector) *sketcher.Edge {
+	t.Helper()
+	for _, edge := range shape.Edges() {
+		if meshPoint(edge.Midpoint()).Sub(midpoint).Length() < 1e-6 {
+			return edge
+		}
+	}
+	t.Fatalf("shape has no edge at %v", midpoint)
+	return nil
+}
+
+func TestChamferDistances(t *testing.T) {
+	cad := NewMakerCad()
+	box := cad.MakeBox(cad.TopPlane, 10, 10, 10, false)
+	top := box.Faces().FirstMatching(func(f *Face) bool { return f.IsPlanar() && f.Normal().Z() > 0.5 })
+	edge := edgeAt(t, box, mesh.Vector{X: 5, Y: 10, Z: 10})
+
+	chamfered, err := cad.ChamferDistances(box, edge, top, 2, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The chamfer removes a right triangle with legs of 2 and 1 along the whole edge
+	volume, err := chamfered.Volume()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(volume-990) > 0.01 {
+		t.Errorf("chamfered volume is %v, want 990", volume)
+	}
+
+	if _, err := cad.ChamferDistances(box, edge, top, 0, 1); err == nil {
+		t.Error("chamfered by a distance of 0")
+	}
+}
+
+func TestChamferRejectsEnds(t *testing.T) {
+	cad := NewMakerCad()
+	// A wall rises from the end of the top back edge of a low block
+	low := cad.MakeBox(cad.TopPlane, 10, 10, 5, false)
+	wall := cad.MakeBox(cad.TopPlane, 5, 10, 10, false).Translated(10, 0, 0)
+	operation, err := low.Combine(ListOfShape{wall})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stepped := operation.Shape()
+	top := stepped.Faces().FirstMatching(func(f *Face) bool {
+		return f.IsPlanar() && f.Normal().Z() > 0.5 && f.DistanceAlong(0, 0, 1) < 6
+	})
+	if top == nil {
+		t.Fatal("stepped block has no low top face")
+	}
+	edge := edgeAt(t, stepped, mesh.Vector{X: 5, Y: 10, Z: 5})
+	if _, err := cad.ChamferDistances(stepped, edge, top, 2, 1); err == nil {
+		t.Error("chamfered an edge ending at a wall")
+	}
+}
@@ -0,0 +1,175 @@
+package makercad
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/marcuswu/makercad/mesh"
+	"github.com/marcuswu/makercad/sketcher"
+	"github.com/marcuswu/makercad/utils"
+
+	"github.com/marcuswu/gooccwrapper/brepgprop"
+	"github.com/marcuswu/gooccwrapper/gprop"
+	"github.com/marcuswu/gooccwrapper/topods"
+)
+
+const (
+	// checkLinearDeflection and checkAngularDeflection are the mesh precision used to look for self-intersections.
+	// The linear deflection is relative to the size of each face.
+	checkLinearDeflection  = 0.01
+	checkAngularDeflection = 0.1
+	// checkVertexTolerance is the distance below which mesh vertices of different faces are considered shared
+	checkVertexTolerance = 1e-4
+)
+
+// ProblemType identifies the kind of a [Problem] found by [Shape.Check]
+type ProblemType int
+
+const (
+	ProblemFreeEdge         ProblemType = iota // an edge bounds only one face, leaving the shell open
+	ProblemNonManifoldEdge                     // an edge bounds more than two faces
+	ProblemDegenerateEdge                      // an edge has no length
+	ProblemDegenerateFace                      // a face has no area
+	ProblemOpenWire                            // the boundary of a face does not close at an edge's vertex
+	ProblemSelfIntersection                    // faces pass through each other
+)
+
+func (t ProblemType) String() string {
+	switch t {
+	case ProblemFreeEdge:
+		return "FreeEdge"
+	case ProblemNonManifoldEdge:
+		return "NonManifoldEdge"
+	case ProblemDegenerateEdge:
+		return "DegenerateEdge"
+	case ProblemDegenerateFace:
+		return "DegenerateFace"
+	case ProblemOpenWire:
+		return "OpenWire"
+	case ProblemSelfIntersection:
+		return "SelfIntersection"
+	}
+	return "Unknown"
+}
+
+// Problem is a validity problem of a shape. Edges and Faces are the geometry involved and Location is a point where the problem is.
+type Problem struct {
+	Type     ProblemType
+	Edges    sketcher.ListOfEdge
+	Faces    ListOfFace
+	Location sketcher.Vector
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s at (%g, %g, %g)", p.Type, p.Location.X, p.Location.Y, p.Location.Z)
+}
+
+// Check looks for validity problems which make a shape unsuitable for export or further booleans: open shells,
+// non-manifold or degenerate geometry, face boundaries which do not close and faces passing through each other.
+// Self-intersections are found between the triangles of a mesh of each face, as the wrapper has no surface
+// intersection, so faces crossing by less than the mesh precision are not reported. Only faces which are neither the
+// same nor adjacent are tested, since the triangles along a shared edge meet without crossing.
+// A valid solid has no problems.
+func (s Shape) Check() ([]Problem, error) {
+	problems := make([]Problem, 0)
+	location := func(p mesh.Vector) sketcher.Vector { return sketcher.Vector{X: p.X, Y: p.Y, Z: p.Z} }
+
+	faces := s.Faces()
+	faceEdges := make([]sketcher.ListOfEdge, len(faces))
+	for i, face := range faces {
+		faceEdges[i] = face.Edges()
+
+		props := gprop.NewGProps()
+		brepgprop.SurfaceProperties(topods.NewShapeFromRef(topods.TopoDSShape(face.face.Face)), props, false, false)
+		if props.Mass() < utils.Confusion {
+			problems = append(problems, Problem{Type: ProblemDegenerateFace, Faces: ListOfFace{face}, Location: location(meshPoint(props.CenterOfMass()))})
+		}
+	}
+
+	for _, edge := range s.Edges() {
+		props := gprop.NewGProps()
+		brepgprop.LinearProperties(topods.NewShapeFromRef(topods.TopoDSShape(edge.Edge.Edge)), props, false, false)
+		at := location(meshPoint(edge.FirstVertex()))
+		if props.Mass() < utils.Confusion {
+			// Degenerate edges, such as the apex of a cone, have no curve to check further
+			problems = append(problems, Problem{Type: ProblemDegenerateEdge, Edges: sketcher.ListOfEdge{edge}, Location: at})
+			continue
+		}
+
+		indexes := edgeFaceIndexes(faceEdges, edge)
+		adjacent := make(ListOfFace, 0, len(indexes))
+		for _, index := range slices.Compact(indexes) {
+			adjacent = append(adjacent, faces[index])
+		}
+		switch {
+		case len(indexes) < 2:
+			problems = append(problems, Problem{Type: ProblemFreeEdge, Edges: sketcher.ListOfEdge{edge}, Faces: adjacent, Location: at})
+		case len(indexes) > 2:
+			problems = append(problems, Problem{Type: ProblemNonManifoldEdge, Edges: sketcher.ListOfEdge{edge}, Faces: adjacent, Location: at})
+		}
+	}
+
+	// Every vertex of a closed face boundary is shared by two edge ends
+	for i, face := range faces {
+		ends := make(sketcher.ListOfVertex, 0, 2*len(faceEdges[i]))
+		for _, edge := range faceEdges[i] {
+			ends = append(ends, edge.Vertexes()...)
+		}
+		for _, edge := range faceEdges[i] {
+			for _, vertex := range edge.Vertexes() {
+				shared := 0
+				for _, end := range ends {
+					if end.IsSame(vertex) {
+						shared++
+					}
+				}
+				if shared < 2 {
+					problems = append(problems, Problem{Type: ProblemOpenWire, Edges: sketcher.ListOfEdge{edge}, Faces: ListOfFace{face},
+						Location: location(meshPoint(vertex.ToPoint()))})
+				}
+			}
+		}
+	}
+
+	// Mesh each face separately to know which faces cross
+	combined := &mesh.Mesh{}
+	owners := make([]int, 0)
+	for i, face := range faces {
+		faceMesh, err := triangulate(ListOfShape{*face.AsShape()}, checkLinearDeflection, checkAngularDeflection)
+		if err != nil {
+			return nil, err
+		}
+		combined.Triangles = append(combined.Triangles, faceMesh.Triangles...)
+		for range faceMesh.Triangles {
+			owners = append(owners, i)
+		}
+	}
+	adjacent := make(map[[2]int]bool)
+	for i := range faces {
+		for j := i + 1; j < len(faces); j++ {
+			for _, edge := range faceEdges[i] {
+				if slices.ContainsFunc(faceEdges[j], edge.IsSame) {
+					adjacent[[2]int{i, j}] = true
+					break
+				}
+			}
+		}
+	}
+	separate := func(first, second int) bool {
+		pair := [2]int{owners[first], owners[second]}
+		return pair[0] != pair[1] && !adjacent[[2]int{min(pair[0], pair[1]), max(pair[0], pair[1])}]
+	}
+
+	reported := make(map[[2]int]bool)
+	for _, intersection := range combined.IntersectionsWhere(checkVertexTolerance, separate) {
+		pair := [2]int{owners[intersection.First], owners[intersection.Second]}
+		if reported[pair] {
+			continue
+		}
+		reported[pair] = true
+		problems = append(problems, Problem{Type: ProblemSelfIntersection, Faces: ListOfFace{faces[pair[0]], faces[pair[1]]},
+			Location: location(intersection.Point)})
+	}
+
+	return problems, nil
+}
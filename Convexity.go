@@ -0,0 +1,238 @@
+package makercad
+
+import (
+	"math"
+	"slices"
+
+	"github.com/marcuswu/makercad/mesh"
+	"github.com/marcuswu/makercad/sketcher"
+
+	"github.com/marcuswu/gooccwrapper/brepadapter"
+	"github.com/marcuswu/gooccwrapper/topexp"
+	"github.com/marcuswu/gooccwrapper/topods"
+)
+
+const (
+	// convexityLinearDeflection and convexityAngularDeflection are the mesh precision used to find curved face normals at edges
+	convexityLinearDeflection  = 0.01
+	convexityAngularDeflection = 0.02
+	// tangentAngle is the largest angle in radians between the face normals at an edge for the faces to be tangent.
+	// It allows for mesh triangles deviating from curved faces.
+	tangentAngle = 0.05
+)
+
+// EdgeConvexity classifies an edge of this shape by the angle between the faces meeting at it
+func (s Shape) EdgeConvexity(edge *sketcher.Edge) sketcher.EdgeConvexity {
+	return s.EdgeConvexities(sketcher.ListOfEdge{edge})[0]
+}
+
+// EdgeConvexities classifies edges of this shape by the angle between the faces meeting at them.
+// A planar face's normal is exact and the direction into it is found from the way its boundary runs along the edge, as
+// the face lies to the left of its edges seen from its normal (see orientedChord). The wrapper has no curve parameters
+// on faces to evaluate a curved surface at an edge, so only a curved face (or a planar face bounded by a full circle) is
+// triangulated, on a copy, and its triangle nearest the edge gives the normal and inward direction there.
+// The edge is convex when each face lies behind the other.
+func (s Shape) EdgeConvexities(edges sketcher.ListOfEdge) []sketcher.EdgeConvexity {
+	faces := s.Faces()
+	faceEdges := make([]sketcher.ListOfEdge, len(faces))
+	for i, face := range faces {
+		faceEdges[i] = face.Edges()
+	}
+	meshes := make(map[int]*mesh.Mesh)
+
+	// frame returns the normal of a face at a point on its boundary and the direction from the point into the face
+	frame := func(index int, edge *sketcher.Edge, point mesh.Vector) (mesh.Vector, mesh.Vector, bool) {
+		if faces[index].IsPlanar() {
+			if chord, ok := orientedChord(faces[index], edge); ok {
+				planeNormal := faces[index].Normal()
+				normal := mesh.Vector{X: planeNormal.X(), Y: planeNormal.Y(), Z: planeNormal.Z()}
+				return normal, normal.Cross(chord), true
+			}
+		}
+
+		faceMesh, ok := meshes[index]
+		if !ok {
+			faceMesh, _ = triangulate(ListOfShape{*faces[index].AsShape()}, convexityLinearDeflection, convexityAngularDeflection)
+			meshes[index] = faceMesh
+		}
+		if faceMesh == nil || len(faceMesh.Triangles) == 0 {
+			return mesh.Vector{}, mesh.Vector{}, false
+		}
+		triangle := faceMesh.Triangles[faceMesh.Closest(point)]
+		return triangle.Normal(), triangle.Centroid().Sub(point).Normalized(), true
+	}
+
+	convexities := make([]sketcher.EdgeConvexity, len(edges))
+	for i, edge := range edges {
+		adjacent := edgeFaceIndexes(faceEdges, edge)
+
+		convexities[i] = sketcher.EdgeBoundary
+		switch {
+		case len(adjacent) == 2 && adjacent[0] == adjacent[1]:
+			// A face meeting itself at a seam
+			convexities[i] = sketcher.EdgeTangent
+		case len(adjacent) == 2:
+			point := pointOnEdge(edge)
+			firstNormal, firstInward, firstOk := frame(adjacent[0], edge, point)
+			secondNormal, secondInward, secondOk := frame(adjacent[1], edge, point)
+			if !firstOk || !secondOk {
+				continue
+			}
+			angle := math.Acos(max(-1, min(1, firstNormal.Dot(secondNormal))))
+			switch {
+			case angle < tangentAngle:
+				convexities[i] = sketcher.EdgeTangent
+			case firstInward.Dot(secondNormal)+secondInward.Dot(firstNormal) < 0:
+				convexities[i] = sketcher.EdgeConvex
+			default:
+				convexities[i] = sketcher.EdgeConcave
+			}
+		}
+	}
+	return convexities
+}
+
+// edgeFaceIndexes returns the index of every face bounded by the edge given the edges of each face.
+// A face is listed twice for a seam edge, which joins the face to itself.
+func edgeFaceIndexes(faceEdges []sketcher.ListOfEdge, edge *sketcher.Edge) []int {
+	indexes := make([]int, 0, 2)
+	for f, others := range faceEdges {
+		for _, other := range others {
+			if other.IsSame(edge) {
+				indexes = append(indexes, f)
+			}
+		}
+	}
+	return indexes
+}
+
+// orientedChord returns the unit direction from the start to the end of an edge of a planar face as the face's boundary
+// runs along it, with the face to the left of the edge seen from the face's normal. The wrapper does not expose edge
+// orientations, so the edges of each wire are followed end to end and the loop's turning direction tells which way it
+// runs: the outer wire, which encloses the largest area, runs counterclockwise and the wires of holes run clockwise.
+// The chord of a line or an arc is parallel to its tangent at the point pointOnEdge returns. A closed edge has no chord.
+func orientedChord(face *Face, edge *sketcher.Edge) (mesh.Vector, bool) {
+	if edge == nil || !(edge.IsLine() || edge.IsArc()) {
+		return mesh.Vector{}, false
+	}
+	planeNormal := face.Normal()
+	normal := mesh.Vector{X: planeNormal.X(), Y: planeNormal.Y(), Z: planeNormal.Z()}
+
+	var chord mesh.Vector
+	found := false
+	edgeArea, outerArea := 0.0, 0.0
+	for ex := topexp.NewExplorer(topods.NewShapeFromRef(topods.TopoDSShape(face.face.Face)), topexp.Wire); ex.More(); ex.Next() {
+		edges := make(sketcher.ListOfEdge, 0)
+		for edgeEx := topexp.NewExplorer(ex.Current(), topexp.Edge); edgeEx.More(); edgeEx.Next() {
+			edges = append(edges, sketcher.NewEdgeFromRef(edgeEx.Current()))
+		}
+		points, forward, ok := wireLoop(edges)
+		if !ok {
+			return mesh.Vector{}, false
+		}
+		area := 0.0
+		for i, p := range points {
+			area += p.Cross(points[(i+1)%len(points)]).Dot(normal) / 2
+		}
+		if math.Abs(area) > math.Abs(outerArea) {
+			outerArea = area
+		}
+		if found {
+			continue
+		}
+		if index := slices.IndexFunc(edges, edge.IsSame); index >= 0 {
+			found, edgeArea = true, area
+			chord = meshPoint(edges[index].LastVertex()).Sub(meshPoint(edges[index].FirstVertex()))
+			if !forward[index] {
+				chord = chord.Scale(-1)
+			}
+		}
+	}
+	if !found || chord.Length() < namingTolerance || edgeArea == 0 {
+		return mesh.Vector{}, false
+	}
+	// The edge's wire runs the right way when it turns counterclockwise for the outer wire or clockwise for a hole
+	outer := math.Abs(edgeArea) == math.Abs(outerArea)
+	if (edgeArea > 0) != outer {
+		chord = chord.Scale(-1)
+	}
+	return chord.Normalized(), true
+}
+
+// wireLoop follows the edges of a wire end to end from the first edge. It returns points around the loop, including
+// points along curved edges, and whether each edge is followed from its first vertex to its last.
+func wireLoop(edges sketcher.ListOfEdge) ([]mesh.Vector, []bool, bool) {
+	if len(edges) == 0 {
+		return nil, nil, false
+	}
+	forward := make([]bool, len(edges))
+	used := make([]bool, len(edges))
+	points := make([]mesh.Vector, 0, 2*len(edges))
+	current := meshPoint(edges[0].FirstVertex())
+	for range edges {
+		next := -1
+		for i, edge := range edges {
+			if used[i] {
+				continue
+			}
+			if meshPoint(edge.FirstVertex()).Sub(current).Length() < namingTolerance {
+				next, forward[i] = i, true
+				break
+			}
+			if meshPoint(edge.LastVertex()).Sub(current).Length() < namingTolerance {
+				next, forward[i] = i, false
+				break
+			}
+		}
+		if next < 0 {
+			return nil, nil, false
+		}
+		used[next] = true
+		along := edgePoints(edges[next])
+		if !forward[next] {
+			slices.Reverse(along)
+		}
+		if edges[next].IsCircle() && !edges[next].IsArc() {
+			// A closed circle ends where it starts and does not repeat its first point
+			points = append(points, along...)
+			continue
+		}
+		current = along[len(along)-1]
+		points = append(points, along[:len(along)-1]...)
+	}
+	return points, forward, true
+}
+
+// edgePoints returns points along an edge from its first vertex to its last. A closed circle gives points around it.
+func edgePoints(edge *sketcher.Edge) []mesh.Vector {
+	count := 2
+	if edge.IsCircle() {
+		count = 4
+	}
+	along, err := edge.PointsAlong(count)
+	if err != nil {
+		// Other curves are followed through their center of mass
+		return []mesh.Vector{meshPoint(edge.FirstVertex()), meshPoint(edge.Center()), meshPoint(edge.LastVertex())}
+	}
+	points := make([]mesh.Vector, len(along))
+	for i, p := range along {
+		points[i] = mesh.Vector{X: p.X, Y: p.Y, Z: p.Z}
+	}
+	return points
+}
+
+// pointOnEdge returns a point on an edge away from its vertices when the edge is a line or an arc, or its first vertex otherwise
+func pointOnEdge(edge *sketcher.Edge) mesh.Vector {
+	if edge.IsLine() {
+		return meshPoint(edge.Midpoint())
+	}
+	if edge.IsArc() {
+		// The center of mass of an arc lies on the line from the center through the middle of the arc
+		center := meshPoint(brepadapter.NewCurve(edge.Edge).ToCircle().Location())
+		middle := meshPoint(edge.Center()).Sub(center)
+		if middle.Length() > namingTolerance {
+			return center.Add(middle.Normalized().Scale(edge.CircleRadius()))
+		}
+	}
+	return meshPoint(edge.FirstVertex())
+}
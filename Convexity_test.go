@@ -0,0 +1,68 @@
+package makercad
+
+import (
+	"testing"
+
+	"github.com/marcuswu/makercad/mesh"
+	"github.com/marcuswu/makercad/sketcher"
+)
+
+func TestOrientedChord(t *testing.T) {
+	cad := NewMakerCad()
+	// A plate with a square hole through it, so its top face has an outer wire and the wire of the hole
+	plate := cad.MakeBox(cad.TopPlane, 10, 10, 1, false)
+	hole := cad.MakeBox(cad.TopPlane, 2, 2, 3, false).Translated(4, 4, -1)
+	operation, err := plate.Remove(ListOfShape{hole})
+	if err != nil {
+		t.Fatal(err)
+	}
+	shape := operation.Shape()
+	top := shape.Faces().FirstMatching(func(f *Face) bool { return f.IsPlanar() && f.Normal().Z() > 0.5 })
+	if top == nil {
+		t.Fatal("plate has no top face")
+	}
+
+	inFace := func(p mesh.Vector) bool {
+		inPlate := p.X > 0 && p.X < 10 && p.Y > 0 && p.Y < 10
+		inHole := p.X > 4 && p.X < 6 && p.Y > 4 && p.Y < 6
+		return inPlate && !inHole
+	}
+	for _, edge := range top.Edges() {
+		chord, ok := orientedChord(top, edge)
+		if !ok {
+			t.Fatalf("no chord for the edge at %v", edge.Midpoint())
+		}
+		// The face lies to the left of the chord seen from the face normal
+		inward := mesh.Vector{Z: 1}.Cross(chord)
+		if point := meshPoint(edge.Midpoint()).Add(inward.Scale(0.1)); !inFace(point) {
+			t.Errorf("chord %v of the edge at %v points the face's inside to %v", chord, edge.Midpoint(), point)
+		}
+	}
+}
+
+func TestEdgeConvexities(t *testing.T) {
+	cad := NewMakerCad()
+	box := cad.MakeBox(cad.TopPlane, 10, 10, 10, false)
+	for _, convexity := range box.EdgeConvexities(box.Edges()) {
+		if convexity != sketcher.EdgeConvex {
+			t.Errorf("box edge is %v, want %v", convexity, sketcher.EdgeConvex)
+		}
+	}
+
+	// A step on top of the box adds concave edges where it meets the box top
+	step := cad.MakeBox(cad.TopPlane, 5, 10, 5, false).Translated(0, 0, 10)
+	operation, err := box.Combine(ListOfShape{step})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stepped := operation.Shape()
+	concave := 0
+	for _, convexity := range stepped.EdgeConvexities(stepped.Edges()) {
+		if convexity == sketcher.EdgeConcave {
+			concave++
+		}
+	}
+	if concave != 1 {
+		t.Errorf("stepped box has %d concave edges, want 1", concave)
+	}
+}
@@ -0,0 +1,195 @@
+package makercad
+
+import (
+	"io"
+	"math"
+	"slices"
+
+	"github.com/marcuswu/makercad/dxf"
+	"github.com/marcuswu/makercad/sketcher"
+	"github.com/marcuswu/makercad/utils"
+)
+
+// DXFOptions controls how [Sketch.ExportDXF] writes a sketch
+type DXFOptions struct {
+	// Units is recorded in the file header so other software can scale the drawing
+	Units dxf.Units
+	// Layer holds the sketch geometry. Defaults to layer "0".
+	Layer string
+	// IncludeConstruction writes construction geometry to ConstructionLayer
+	IncludeConstruction bool
+	// ConstructionLayer holds construction geometry. Defaults to "CONSTRUCTION".
+	ConstructionLayer string
+}
+
+// ExportDXF writes the lines, arcs and circles of the solved sketch to w as a DXF drawing in sketch plane coordinates.
+// Construction geometry is left out unless requested in the options.
+func (s *Sketch) ExportDXF(w io.Writer, opts DXFOptions) error {
+	layer := opts.Layer
+	if layer == "" {
+		layer = "0"
+	}
+	constructionLayer := opts.ConstructionLayer
+	if constructionLayer == "" {
+		constructionLayer = "CONSTRUCTION"
+	}
+
+	drawing := &dxf.Drawing{Units: opts.Units, Entities: make([]dxf.Entity, 0)}
+	for _, entity := range s.solver.Entities() {
+		entityLayer := layer
+		if entity.IsConstruction() {
+			if !opts.IncludeConstruction {
+				continue
+			}
+			entityLayer = constructionLayer
+		}
+
+		switch e := entity.(type) {
+		case *sketcher.Line:
+			drawing.Entities = append(drawing.Entities, &dxf.Line{
+				Layer: entityLayer,
+				Start: dxf.Point{X: e.Start.X, Y: e.Start.Y},
+				End:   dxf.Point{X: e.End.X, Y: e.End.Y},
+			})
+		case *sketcher.Arc:
+			drawing.Entities = append(drawing.Entities, &dxf.Arc{
+				Layer:      entityLayer,
+				Center:     dxf.Point{X: e.Center.X, Y: e.Center.Y},
+				Radius:     math.Hypot(e.Start.X-e.Center.X, e.Start.Y-e.Center.Y),
+				StartAngle: utils.ToDegrees(math.Atan2(e.Start.Y-e.Center.Y, e.Start.X-e.Center.X)),
+				EndAngle:   utils.ToDegrees(math.Atan2(e.End.Y-e.Center.Y, e.End.X-e.Center.X)),
+			})
+		case *sketcher.Circle:
+			drawing.Entities = append(drawing.Entities, &dxf.Circle{
+				Layer:  entityLayer,
+				Center: dxf.Point{X: e.Center.X, Y: e.Center.Y},
+				Radius: e.Radius,
+			})
+		}
+	}
+
+	return dxf.Write(w, drawing)
+}
+
+// ImportDXF reads LINE, ARC, CIRCLE, LWPOLYLINE, ELLIPSE and SPLINE entities from a DXF file into the sketch.
+// Only entities on the listed layers are imported; all layers are imported if none are listed.
+// Drawing coordinates are used as sketch coordinates. Ellipses and splines are approximated with lines.
+// Endpoints which meet are constrained coincident so the result can be used with [NewFace].
+func (s *Sketch) ImportDXF(reader io.Reader, layers ...string) ([]sketcher.Entity, error) {
+	drawing, err := dxf.Read(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	entities := make([]sketcher.Entity, 0, len(drawing.Entities))
+	for _, entity := range drawing.Entities {
+		if len(layers) > 0 && !slices.Contains(layers, entity.EntityLayer()) {
+			continue
+		}
+		switch e := entity.(type) {
+		case *dxf.Line:
+			entities = append(entities, s.solver.CreateLine(e.Start.X, e.Start.Y, e.End.X, e.End.Y))
+		case *dxf.Arc:
+			entities = append(entities, s.dxfArc(e.Center, e.Radius, utils.ToRadians(e.StartAngle), utils.ToRadians(e.EndAngle)))
+		case *dxf.Circle:
+			entities = append(entities, s.solver.CreateCircle(e.Center.X, e.Center.Y, e.Radius))
+		case *dxf.Polyline:
+			entities = append(entities, s.dxfPolyline(e)...)
+		case *dxf.Ellipse:
+			radius := math.Hypot(e.MajorAxis.X, e.MajorAxis.Y)
+			rotation := math.Atan2(e.MajorAxis.Y, e.MajorAxis.X)
+			switch {
+			case e.IsCircular() && e.IsFull():
+				entities = append(entities, s.solver.CreateCircle(e.Center.X, e.Center.Y, radius))
+			case e.IsCircular():
+				entities = append(entities, s.dxfArc(e.Center, radius, rotation+e.StartParam, rotation+e.EndParam))
+			default:
+				entities = append(entities, s.dxfPoints(e.Points(64))...)
+			}
+		case *dxf.Spline:
+			entities = append(entities, s.dxfPoints(e.Points(max(32, 8*len(e.ControlPoints))))...)
+		}
+	}
+
+	s.constrainCoincidentEndpoints(entities)
+	return entities, nil
+}
+
+func (s *Sketch) dxfArc(center dxf.Point, radius float64, startAngle float64, endAngle float64) *sketcher.Arc {
+	return s.solver.CreateArc(
+		center.X, center.Y,
+		center.X+radius*math.Cos(startAngle), center.Y+radius*math.Sin(startAngle),
+		center.X+radius*math.Cos(endAngle), center.Y+radius*math.Sin(endAngle),
+	)
+}
+
+// dxfPolyline creates a line or arc for each polyline segment
+func (s *Sketch) dxfPolyline(polyline *dxf.Polyline) []sketcher.Entity {
+	count := len(polyline.Vertices)
+	segments := count - 1
+	if polyline.Closed {
+		segments = count
+	}
+
+	entities := make([]sketcher.Entity, 0, max(segments, 0))
+	for i := 0; i < segments; i++ {
+		start, end := polyline.Vertices[i], polyline.Vertices[(i+1)%count]
+		if start.Bulge == 0 {
+			entities = append(entities, s.solver.CreateLine(start.X, start.Y, end.X, end.Y))
+			continue
+		}
+
+		// The bulge is the tangent of a quarter of the included angle. The center sits off the chord's midpoint.
+		dx, dy := end.X-start.X, end.Y-start.Y
+		chord := math.Hypot(dx, dy)
+		if chord == 0 {
+			continue
+		}
+		offset := chord / (2 * math.Tan(2*math.Atan(start.Bulge)))
+		centerX := (start.X+end.X)/2 - dy/chord*offset
+		centerY := (start.Y+end.Y)/2 + dx/chord*offset
+		if start.Bulge > 0 {
+			entities = append(entities, s.solver.CreateArc(centerX, centerY, start.X, start.Y, end.X, end.Y))
+		} else {
+			entities = append(entities, s.solver.CreateArc(centerX, centerY, end.X, end.Y, start.X, start.Y))
+		}
+	}
+	return entities
+}
+
+// dxfPoints creates lines joining consecutive points
+func (s *Sketch) dxfPoints(points []dxf.Point) []sketcher.Entity {
+	entities := make([]sketcher.Entity, 0, len(points))
+	for i := 1; i < len(points); i++ {
+		start, end := points[i-1], points[i]
+		if start == end {
+			continue
+		}
+		entities = append(entities, s.solver.CreateLine(start.X, start.Y, end.X, end.Y))
+	}
+	return entities
+}
+
+// constrainCoincidentEndpoints makes the endpoints of lines and arcs which meet coincident
+func (s *Sketch) constrainCoincidentEndpoints(entities []sketcher.Entity) {
+	endpoints := make([]*sketcher.Point, 0, len(entities)*2)
+	for _, entity := range entities {
+		switch e := entity.(type) {
+		case *sketcher.Line:
+			endpoints = append(endpoints, e.Start, e.End)
+		case *sketcher.Arc:
+			endpoints = append(endpoints, e.Start, e.End)
+		}
+	}
+
+	// Constrain each endpoint to the first endpoint found at its location
+	firsts := make([]*sketcher.Point, 0, len(endpoints))
+	for _, point := range endpoints {
+		first := slices.IndexFunc(firsts, func(p *sketcher.Point) bool { return p.IsConnectedTo(point) })
+		if first < 0 {
+			firsts = append(firsts, point)
+			continue
+		}
+		point.Coincident(firsts[first])
+	}
+}
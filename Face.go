@@ -2,10 +2,12 @@ package makercad
 
 import (
 	"errors"
+	"fmt"
 	"math"
 	"slices"
 
 	floatUtils "github.com/marcuswu/dlineate/utils"
+	"github.com/marcuswu/makercad/selector"
 	"github.com/marcuswu/makercad/sketcher"
 	"github.com/marcuswu/makercad/utils"
 	"github.com/rs/zerolog/log"
@@ -118,6 +120,79 @@ func (l ListOfFace) SortByZ(inverse bool) {
 	})
 }
 
+// Select returns the faces matching a selector string such as ">Z", "|Z and <X" or "%CYLINDER" (see the selector package).
+// Faces are placed by their center of mass and the direction selectors apply to planar faces by their normal.
+func (l ListOfFace) Select(expression string) (ListOfFace, error) {
+	node, err := selector.Parse(expression)
+	if err != nil {
+		return nil, err
+	}
+	return selector.Evaluate(node, l, func(node selector.Node, faces ListOfFace) (ListOfFace, error) {
+		return faces.selectNode(node)
+	})
+}
+
+// selectNode evaluates a selector node which is not a boolean operator
+func (l ListOfFace) selectNode(node selector.Node) (ListOfFace, error) {
+	switch n := node.(type) {
+	case selector.Extreme:
+		direction := gp.NewVec(n.Direction.X, n.Direction.Y, n.Direction.Z)
+		along := func(f *Face) float64 {
+			center := f.getCenter()
+			return gp.NewVec(center.X(), center.Y(), center.Z()).Dot(direction) / direction.Magnitude()
+		}
+		sorted := slices.Clone(l)
+		sorted.Sort(func(a, b *Face) int {
+			if n.Maximum {
+				return floatUtils.StandardFloatCompare(along(b), along(a))
+			}
+			return floatUtils.StandardFloatCompare(along(a), along(b))
+		})
+		return selector.Group(sorted, func(a, b *Face) bool { return floatUtils.StandardFloatCompare(along(a), along(b)) == 0 }, n.Index), nil
+	case selector.Parallel:
+		direction := gp.NewDir(n.Direction.X, n.Direction.Y, n.Direction.Z)
+		return l.Matching(func(f *Face) bool { return f.IsPlanar() && f.Normal().IsParallel(direction) }), nil
+	case selector.Perpendicular:
+		direction := gp.NewDir(n.Direction.X, n.Direction.Y, n.Direction.Z)
+		return l.Matching(func(f *Face) bool {
+			return f.IsPlanar() && floatUtils.StandardFloatCompare(f.Normal().Dot(direction), 0) == 0
+		}), nil
+	case selector.Facing:
+		direction := gp.NewDir(n.Direction.X, n.Direction.Y, n.Direction.Z)
+		if n.Opposite {
+			direction = gp.NewDir(-n.Direction.X, -n.Direction.Y, -n.Direction.Z)
+		}
+		return l.Matching(func(f *Face) bool { return f.IsPlanar() && f.Normal().IsEqual(direction) }), nil
+	case selector.Type:
+		surfaces := map[string]geomabs.SurfaceType{
+			"PLANE":    geomabs.Plane,
+			"CYLINDER": geomabs.Cylinder,
+			"CONE":     geomabs.Cone,
+			"SPHERE":   geomabs.Sphere,
+			"TORUS":    geomabs.Torus,
+		}
+		surfaceType, ok := surfaces[n.Name]
+		if !ok {
+			return nil, fmt.Errorf("unknown face type %s, expected %%PLANE, %%CYLINDER, %%CONE, %%SPHERE or %%TORUS", n.Name)
+		}
+		return l.Matching(func(f *Face) bool { return brepadapter.NewSurface(f.face).Type() == surfaceType }), nil
+	}
+	return nil, fmt.Errorf("selector %s does not apply to faces", node)
+}
+
+// SharedEdges returns the edges where a face of this list meets a face of the other list, such as the edges between
+// a top face and the cylindrical faces of a shape
+func (l ListOfFace) SharedEdges(other ListOfFace) sketcher.ListOfEdge {
+	otherEdges := other.Edges()
+	edges := make(sketcher.ListOfEdge, 0)
+	for _, edge := range l.Edges() {
+		if slices.ContainsFunc(otherEdges, edge.IsSame) && !slices.ContainsFunc(edges, edge.IsSame) {
+			edges = append(edges, edge)
+		}
+	}
+	return edges
+}
+
 // Return the edges which are contained within this Face
 func (l ListOfFace) Edges() sketcher.ListOfEdge {
 	le := sketcher.ListOfEdge{}
@@ -129,6 +204,7 @@ func (l ListOfFace) Edges() sketcher.ListOfEdge {
 
 // NewFace creates a face based on the provided sketch. Ignores construction entities and any entities which do not create a Wire (like Points).
 // NewFace attempts to create the face by edges ordered by connectivity. If it cannot determine order by connectivity, a non-manifold Face may be returned.
+// A sketch with several separate outlines, such as text, makes one face per outline with [NewFaces].
 func NewFace(s *Sketch) *Face {
 	brepbuilderapi.SetPrecision(0.0001)
 	wires := make([]topods.Wire, 0)
@@ -341,11 +417,31 @@ func (f *Face) AsShape() *Shape {
 
 // Mirror mirrors this face across the specified plane. This may flip the normal
 func (f *Face) Mirror(plane *sketcher.PlaneParameters) (*Face, error) {
-	coord := plane.Ax2()
-	gptrans := gp.NewTrsf()
-	gptrans.SetMirrorAx2(coord)
-	trans := brepbuilderapi.NewTransform(f.AsShape().Shape, gptrans)
-	return &Face{topods.NewFaceFromRef(topods.TopoDSFace(trans.Shape().Shape))}, nil
+	return f.Mirrored(plane), nil
+}
+
+// Translated returns a copy of this Face moved by x, y and z
+func (f *Face) Translated(x float64, y float64, z float64) *Face {
+	return faceOf(f.AsShape().Translated(x, y, z))
+}
+
+// Rotated returns a copy of this Face rotated about the axis by the angle in radians (see [Shape.Rotated])
+func (f *Face) Rotated(axis *sketcher.Axis, angle float64) *Face {
+	return faceOf(f.AsShape().Rotated(axis, angle))
+}
+
+// Mirrored returns a copy of this Face reflected across the plane. This may flip the normal
+func (f *Face) Mirrored(plane *sketcher.PlaneParameters) *Face {
+	return faceOf(f.AsShape().Mirrored(plane))
+}
+
+// Placed returns a copy of this Face moved so that it sits on the plane to as it sat on the plane from
+func (f *Face) Placed(from *sketcher.PlaneParameters, to *sketcher.PlaneParameters) *Face {
+	return faceOf(f.AsShape().Placed(from, to))
+}
+
+func faceOf(shape Shape) *Face {
+	return &Face{topods.NewFaceFromRef(topods.TopoDSFace(shape.Shape.Shape))}
 }
 
 // HasEdge returns whether this Face contains the specified edge
@@ -359,6 +455,11 @@ func (f *Face) HasEdge(edge topods.Edge) bool {
 	return false
 }
 
+// isSame returns whether this Face and other are the same face of a shape
+func (f *Face) isSame(other *Face) bool {
+	return topods.NewShapeFromRef(topods.TopoDSShape(f.face.Face)).IsEqual(topods.TopoDSShape(other.face.Face))
+}
+
 // IsAlignedWithFace returns wither this Face and the provided Face have equivalent normals
 func (f *Face) IsAlignedWithFace(other *Face) bool {
 	surface := brepadapter.NewSurface(f.face)
@@ -0,0 +1,199 @@
+package makercad
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/marcuswu/makercad/mesh"
+	"github.com/marcuswu/makercad/sketcher"
+
+	"github.com/marcuswu/gooccwrapper/brepalgoapi"
+	"github.com/marcuswu/gooccwrapper/brepbuilderapi"
+	"github.com/marcuswu/gooccwrapper/brepprimapi"
+	"github.com/marcuswu/gooccwrapper/geom"
+	"github.com/marcuswu/gooccwrapper/gp"
+)
+
+// holeLead is how far above the face each hole starts, so the cut does not depend on faces lying exactly on the face
+const holeLead = 0.01
+
+type HoleType int
+
+const (
+	HoleTypeSimple HoleType = iota
+	HoleTypeCounterbore
+	HoleTypeCountersink
+)
+
+func (t HoleType) String() string {
+	switch t {
+	case HoleTypeSimple:
+		return "Simple"
+	case HoleTypeCounterbore:
+		return "Counterbore"
+	case HoleTypeCountersink:
+		return "Countersink"
+	default:
+		return "Unknown"
+	}
+}
+
+// HoleFit chooses the hole diameter of a standard screw: a clearance hole which fits it closely, normally or loosely,
+// or a tap drill hole for cutting a thread
+type HoleFit int
+
+const (
+	HoleFitNormal HoleFit = iota
+	HoleFitClose
+	HoleFitLoose
+	HoleFitTap
+)
+
+func (f HoleFit) String() string {
+	switch f {
+	case HoleFitNormal:
+		return "Normal"
+	case HoleFitClose:
+		return "Close"
+	case HoleFitLoose:
+		return "Loose"
+	case HoleFitTap:
+		return "Tap"
+	default:
+		return "Unknown"
+	}
+}
+
+// HoleSpec describes a hole. Sizes come from the standard Screw (see [LookupScrew]) unless they are set directly.
+type HoleSpec struct {
+	Type  HoleType
+	Screw string
+	Fit   HoleFit
+	// Diameter, HeadDiameter and HeadDepth override the sizes of Screw when non-zero. HeadDiameter is the diameter of a
+	// counterbore or countersink and HeadDepth is the depth of a counterbore.
+	Diameter     float64
+	HeadDiameter float64
+	HeadDepth    float64
+	// CountersinkAngle is the included angle of a countersink in radians, by default 90° for metric and 82° for imperial
+	// screws
+	CountersinkAngle float64
+	// Depth is the depth of a blind hole. A depth of 0 makes a hole through the whole shape.
+	Depth float64
+}
+
+// holeProfile returns the outline of half the cross section of a hole as radius and depth pairs, starting on its axis at
+// the surface and ending on its axis at the bottom
+func (spec HoleSpec) holeProfile(through float64) ([][2]float64, error) {
+	size := ScrewSize{CountersinkAngle: metricCountersinkAngle}
+	if spec.Screw != "" {
+		var err error
+		if size, err = LookupScrew(spec.Screw); err != nil {
+			return nil, err
+		}
+	}
+	diameter := cmp.Or(spec.Diameter, size.Clearance(spec.Fit))
+	depth := cmp.Or(spec.Depth, through)
+	if diameter <= 0 {
+		return nil, errors.New("hole requires a screw size or diameter")
+	}
+	if depth <= 0 {
+		return nil, errors.New("hole depth must be positive")
+	}
+	radius := diameter / 2
+
+	profile := [][2]float64{{0, 0}}
+	switch spec.Type {
+	case HoleTypeSimple:
+		profile = append(profile, [2]float64{radius, 0})
+	case HoleTypeCounterbore:
+		headRadius := cmp.Or(spec.HeadDiameter, size.CounterboreDiameter) / 2
+		headDepth := cmp.Or(spec.HeadDepth, size.CounterboreDepth)
+		if headRadius <= radius || headDepth <= 0 || headDepth >= depth {
+			return nil, fmt.Errorf("counterbore of diameter %f and depth %f does not fit a hole of diameter %f and depth %f",
+				headRadius*2, headDepth, diameter, depth)
+		}
+		profile = append(profile, [2]float64{headRadius, 0}, [2]float64{headRadius, headDepth}, [2]float64{radius, headDepth})
+	case HoleTypeCountersink:
+		headRadius := cmp.Or(spec.HeadDiameter, size.CountersinkDiameter) / 2
+		angle := cmp.Or(spec.CountersinkAngle, size.CountersinkAngle)
+		if angle <= 0 || angle >= math.Pi {
+			return nil, errors.New("countersink angle must be between 0 and π radians")
+		}
+		headDepth := (headRadius - radius) / math.Tan(angle/2)
+		if headRadius <= radius || headDepth >= depth {
+			return nil, fmt.Errorf("countersink of diameter %f does not fit a hole of diameter %f and depth %f",
+				headRadius*2, diameter, depth)
+		}
+		profile = append(profile, [2]float64{headRadius, 0}, [2]float64{radius, headDepth})
+	default:
+		return nil, fmt.Errorf("unknown hole type %v", spec.Type)
+	}
+	return append(profile, [2]float64{radius, depth}, [2]float64{0, depth}), nil
+}
+
+// Hole cuts a hole described by the spec into this Shape at each point. The holes go into the Shape against the
+// normal of the planar face, starting where each point projects onto the face. All holes are cut with a single boolean.
+// Holes without a depth are sized through the Shape by the box around its edges, which needs no meshing unless the
+// Shape has edges other than lines, circles and arcs.
+func (s Shape) Hole(face *Face, points []*sketcher.Point, spec HoleSpec) (*CadOperation, error) {
+	if !face.IsPlanar() {
+		return nil, errors.New("holes can only be placed on planar faces")
+	}
+	if len(points) < 1 {
+		return nil, errors.New("hole requires at least one point")
+	}
+	box, err := EdgesBoundingBox(s.Edges())
+	if err != nil {
+		if box, err = s.BoundingBox(); err != nil {
+			return nil, err
+		}
+	}
+	size := box.Size()
+	through := math.Sqrt(size.X*size.X+size.Y*size.Y+size.Z*size.Z) * 2
+	profile, err := spec.holeProfile(through)
+	if err != nil {
+		return nil, err
+	}
+	for i := range profile {
+		if profile[i][1] == 0 {
+			profile[i][1] = -holeLead
+		}
+	}
+
+	normal := face.Normal()
+	axis := mesh.Vector{X: -normal.X(), Y: -normal.Y(), Z: -normal.Z()}
+	// Any direction perpendicular to the axis works for the plane the profile is drawn on
+	radial := axis.Cross(mesh.Vector{X: 1})
+	if radial.Length() < 0.1 {
+		radial = axis.Cross(mesh.Vector{Y: 1})
+	}
+	radial = radial.Normalized()
+	origin := meshPoint(face.Plane().Location())
+
+	holes := make(ListOfShape, 0, len(points))
+	for _, p := range points {
+		center := meshPoint(p.Convert())
+		center = center.Add(axis.Scale(origin.Sub(center).Dot(axis)))
+		wire := brepbuilderapi.NewMakeWire()
+		// The last edge closes the outline along the axis
+		for i := range profile {
+			next := profile[(i+1)%len(profile)]
+			start := center.Add(radial.Scale(profile[i][0])).Add(axis.Scale(profile[i][1]))
+			end := center.Add(radial.Scale(next[0])).Add(axis.Scale(next[1]))
+			segment := geom.MakeSegment(gp.NewPnt(start.X, start.Y, start.Z), gp.NewPnt(end.X, end.Y, end.Z))
+			wire.AddEdge(brepbuilderapi.NewMakeEdge(segment).ToTopoDSEdge())
+		}
+		section := brepbuilderapi.NewMakeFace(wire.ToTopoDSWire()).ToTopoDSFace()
+		ax1 := gp.NewAx1(gp.NewPnt(center.X, center.Y, center.Z), gp.NewDir(axis.X, axis.Y, axis.Z))
+		holes = append(holes, Shape{brepprimapi.NewMakeRevol(section, ax1, 2*math.Pi).Shape()})
+	}
+
+	operation := brepalgoapi.NewCut().ToBooleanOperation()
+	operation.SetTools(holes.ToCascadeList())
+	operation.SetArguments(ListOfShape{s}.ToCascadeList())
+	operation.Build()
+
+	return NewCadOperation(holes, &operation), nil
+}
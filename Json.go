@@ -0,0 +1,334 @@
+package makercad
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/marcuswu/makercad/sketcher"
+)
+
+// sketchJSON is the serialized form of a sketch.
+// Constraints refer to entities by index, to their points as "<index>.start", "<index>.end" or "<index>.center"
+// and to the sketch's own geometry as "origin", "xAxis" and "yAxis".
+type sketchJSON struct {
+	Plane       planeJSON        `json:"plane"`
+	Entities    []entityJSON     `json:"entities"`
+	Constraints []constraintJSON `json:"constraints"`
+}
+
+type planeJSON struct {
+	Location   [3]float64 `json:"location"`
+	Normal     [3]float64 `json:"normal"`
+	XDirection [3]float64 `json:"xDirection"`
+}
+
+type entityJSON struct {
+	Type         string      `json:"type"`
+	Construction bool        `json:"construction,omitempty"`
+	Reference    bool        `json:"reference,omitempty"`
+	Point        *[2]float64 `json:"point,omitempty"`
+	Center       *[2]float64 `json:"center,omitempty"`
+	Start        *[2]float64 `json:"start,omitempty"`
+	End          *[2]float64 `json:"end,omitempty"`
+	Radius       float64     `json:"radius,omitempty"`
+}
+
+type constraintJSON struct {
+	Type     string   `json:"type"`
+	Entities []string `json:"entities"`
+	Value    float64  `json:"value,omitempty"`
+}
+
+func pointJSON(p *sketcher.Point) *[2]float64 {
+	return &[2]float64{p.X, p.Y}
+}
+
+// MarshalJSON serializes the sketch plane, entities with their construction flags and current (solved) positions, and constraints.
+// Construction geometry the solver created to implement a constraint is left out since it is recreated with the constraint.
+func (s *Sketch) MarshalJSON() ([]byte, error) {
+	coordinates := s.solver.CoordinateSystem()
+	location, normal, xDir := coordinates.Location(), coordinates.Direction(), coordinates.XDirection()
+	data := sketchJSON{
+		Plane: planeJSON{
+			Location:   [3]float64{location.X(), location.Y(), location.Z()},
+			Normal:     [3]float64{normal.X(), normal.Y(), normal.Z()},
+			XDirection: [3]float64{xDir.X(), xDir.Y(), xDir.Z()},
+		},
+		Entities:    make([]entityJSON, 0, len(s.solver.Entities())),
+		Constraints: make([]constraintJSON, 0, len(s.solver.Constraints())),
+	}
+
+	created := make(map[sketcher.Entity]bool)
+	for _, constraint := range s.solver.Constraints() {
+		for _, entity := range constraint.Created {
+			created[entity] = true
+		}
+	}
+	refs := map[sketcher.Entity]string{
+		s.solver.Origin(): "origin",
+		s.solver.XAxis():  "xAxis",
+		s.solver.YAxis():  "yAxis",
+	}
+	for _, entity := range s.solver.Entities() {
+		if created[entity] {
+			continue
+		}
+		index := strconv.Itoa(len(data.Entities))
+		refs[entity] = index
+		e := entityJSON{Construction: entity.IsConstruction(), Reference: s.IsReference(entity)}
+		switch v := entity.(type) {
+		case *sketcher.Point:
+			e.Type = "point"
+			e.Point = pointJSON(v)
+		case *sketcher.Line:
+			e.Type = "line"
+			e.Start, e.End = pointJSON(v.Start), pointJSON(v.End)
+			refs[v.Start], refs[v.End] = index+".start", index+".end"
+		case *sketcher.Arc:
+			e.Type = "arc"
+			e.Center, e.Start, e.End = pointJSON(v.Center), pointJSON(v.Start), pointJSON(v.End)
+			refs[v.Center], refs[v.Start], refs[v.End] = index+".center", index+".start", index+".end"
+		case *sketcher.Circle:
+			e.Type = "circle"
+			e.Center, e.Radius = pointJSON(v.Center), v.Radius
+			refs[v.Center] = index + ".center"
+		default:
+			return nil, fmt.Errorf("cannot serialize entity %v", entity)
+		}
+		data.Entities = append(data.Entities, e)
+	}
+
+	for _, constraint := range s.solver.Constraints() {
+		c := constraintJSON{Type: constraint.Type.String(), Entities: make([]string, 0, len(constraint.Entities)), Value: constraint.Value}
+		for _, entity := range constraint.Entities {
+			ref, ok := refs[entity]
+			if !ok {
+				return nil, fmt.Errorf("%s constraint refers to an entity outside the sketch", constraint.Type)
+			}
+			c.Entities = append(c.Entities, ref)
+		}
+		data.Constraints = append(data.Constraints, c)
+	}
+
+	return json.Marshal(data)
+}
+
+// UnmarshalSketch recreates a sketch serialized with [Sketch.MarshalJSON]. Entities start at their serialized positions,
+// so a sketch which was solved when serialized is ready to use. Reference geometry is restored as fixed geometry
+// without the edge it was projected from.
+func UnmarshalSketch(data []byte) (*Sketch, error) {
+	var sketchData sketchJSON
+	if err := json.Unmarshal(data, &sketchData); err != nil {
+		return nil, err
+	}
+
+	p := sketchData.Plane
+	sketch := newSketch(sketcher.NewPlaneParametersFromVectors(
+		sketcher.NewVectorFromValues(p.Location[0], p.Location[1], p.Location[2]),
+		sketcher.NewVectorFromValues(p.Normal[0], p.Normal[1], p.Normal[2]),
+		sketcher.NewVectorFromValues(p.XDirection[0], p.XDirection[1], p.XDirection[2]),
+	))
+	solver := sketch.solver
+
+	entities := make([]sketcher.Entity, 0, len(sketchData.Entities))
+	for i, e := range sketchData.Entities {
+		var entity sketcher.Entity
+		switch {
+		case e.Type == "point" && e.Point != nil:
+			entity = solver.CreatePoint(e.Point[0], e.Point[1])
+		case e.Type == "line" && e.Start != nil && e.End != nil:
+			entity = solver.CreateLine(e.Start[0], e.Start[1], e.End[0], e.End[1])
+		case e.Type == "arc" && e.Center != nil && e.Start != nil && e.End != nil:
+			entity = solver.CreateArc(e.Center[0], e.Center[1], e.Start[0], e.Start[1], e.End[0], e.End[1])
+		case e.Type == "circle" && e.Center != nil:
+			entity = solver.CreateCircle(e.Center[0], e.Center[1], e.Radius)
+		default:
+			return nil, fmt.Errorf("entity %d: invalid %q entity", i, e.Type)
+		}
+		entity.SetConstruction(e.Construction)
+		entities = append(entities, entity)
+	}
+
+	resolve := func(ref string) (sketcher.Entity, error) {
+		switch ref {
+		case "origin":
+			return solver.Origin(), nil
+		case "xAxis":
+			return solver.XAxis(), nil
+		case "yAxis":
+			return solver.YAxis(), nil
+		}
+		indexText, part, _ := strings.Cut(ref, ".")
+		index, err := strconv.Atoi(indexText)
+		if err != nil || index < 0 || index >= len(entities) {
+			return nil, fmt.Errorf("unknown entity %q", ref)
+		}
+		var entity sketcher.Entity
+		switch e := entities[index].(type) {
+		case *sketcher.Point:
+			if part == "" {
+				entity = e
+			}
+		case *sketcher.Line:
+			switch part {
+			case "":
+				entity = e
+			case "start":
+				entity = e.Start
+			case "end":
+				entity = e.End
+			}
+		case *sketcher.Arc:
+			switch part {
+			case "":
+				entity = e
+			case "start":
+				entity = e.Start
+			case "end":
+				entity = e.End
+			case "center":
+				entity = e.Center
+			}
+		case *sketcher.Circle:
+			switch part {
+			case "":
+				entity = e
+			case "center":
+				entity = e.Center
+			}
+		}
+		if entity == nil {
+			return nil, fmt.Errorf("unknown entity %q", ref)
+		}
+		return entity, nil
+	}
+
+	for i, c := range sketchData.Constraints {
+		constraintEntities := make([]sketcher.Entity, 0, len(c.Entities))
+		for _, ref := range c.Entities {
+			entity, err := resolve(ref)
+			if err != nil {
+				return nil, fmt.Errorf("constraint %d: %w", i, err)
+			}
+			constraintEntities = append(constraintEntities, entity)
+		}
+		if err := applyConstraint(solver, c.Type, constraintEntities, c.Value); err != nil {
+			return nil, fmt.Errorf("constraint %d: %w", i, err)
+		}
+	}
+
+	for i, e := range sketchData.Entities {
+		if e.Reference {
+			sketch.references[entities[i]] = nil
+		}
+	}
+	return sketch, nil
+}
+
+// applyConstraint adds a constraint identified by the name of its [sketcher.ConstraintType]
+func applyConstraint(solver sketcher.SketchSolver, name string, entities []sketcher.Entity, value float64) error {
+	constraintType := sketcher.ConstraintType(-1)
+	for t := sketcher.ConstraintCoincident; t <= sketcher.ConstraintFixed; t++ {
+		if t.String() == name {
+			constraintType = t
+		}
+	}
+
+	if constraintType < 0 {
+		return fmt.Errorf("unknown constraint type %q", name)
+	}
+
+	counts := map[sketcher.ConstraintType]int{
+		sketcher.ConstraintHorizontalLine: 1,
+		sketcher.ConstraintVerticalLine:   1,
+		sketcher.ConstraintLineLength:     1,
+		sketcher.ConstraintCurveDiameter:  1,
+		sketcher.ConstraintFixed:          1,
+		sketcher.ConstraintPointSymmetric: 3,
+	}
+	count, ok := counts[constraintType]
+	if !ok {
+		count = 2
+	}
+	if len(entities) != count {
+		return fmt.Errorf("%s constraint requires %d entities", name, count)
+	}
+
+	// Check the entity types the solver methods expect
+	points := make([]*sketcher.Point, len(entities))
+	lines := make([]*sketcher.Line, len(entities))
+	for i, entity := range entities {
+		points[i], lines[i] = sketcher.AsPoint(entity), sketcher.AsLine(entity)
+	}
+	wrongTypes := fmt.Errorf("%s constraint has entities of the wrong type", name)
+
+	switch constraintType {
+	case sketcher.ConstraintCoincident:
+		solver.Coincident(entities[0], entities[1])
+	case sketcher.ConstraintPointVerticalDistance, sketcher.ConstraintPointHorizontalDistance, sketcher.ConstraintPointProjectedDistance:
+		if points[0] == nil {
+			return wrongTypes
+		}
+		switch constraintType {
+		case sketcher.ConstraintPointVerticalDistance:
+			solver.PointVerticalDistance(points[0], entities[1], value)
+		case sketcher.ConstraintPointHorizontalDistance:
+			solver.PointHorizontalDistance(points[0], entities[1], value)
+		default:
+			solver.PointProjectedDistance(points[0], entities[1], value)
+		}
+	case sketcher.ConstraintLineMidpoint:
+		if lines[0] == nil {
+			return wrongTypes
+		}
+		solver.LineMidpoint(lines[0], entities[1])
+	case sketcher.ConstraintLineAngle:
+		if lines[0] == nil || lines[1] == nil {
+			return wrongTypes
+		}
+		solver.LineAngle(lines[0], lines[1], value)
+	case sketcher.ConstraintArcLineTangent:
+		arc := sketcher.AsArc(entities[0])
+		if arc == nil || lines[1] == nil {
+			return wrongTypes
+		}
+		solver.ArcLineTangent(arc, lines[1])
+	case sketcher.ConstraintDistance:
+		solver.Distance(entities[0], entities[1], value)
+	case sketcher.ConstraintHorizontalLine, sketcher.ConstraintVerticalLine, sketcher.ConstraintLineLength:
+		if lines[0] == nil {
+			return wrongTypes
+		}
+		switch constraintType {
+		case sketcher.ConstraintHorizontalLine:
+			solver.HorizontalLine(lines[0])
+		case sketcher.ConstraintVerticalLine:
+			solver.VerticalLine(lines[0])
+		default:
+			solver.LineLength(lines[0], value)
+		}
+	case sketcher.ConstraintHorizontalPoints, sketcher.ConstraintVerticalPoints:
+		if points[0] == nil || points[1] == nil {
+			return wrongTypes
+		}
+		if constraintType == sketcher.ConstraintHorizontalPoints {
+			solver.HorizontalPoints(points[0], points[1])
+		} else {
+			solver.VerticalPoints(points[0], points[1])
+		}
+	case sketcher.ConstraintPointSymmetric:
+		if points[0] == nil || points[1] == nil || lines[2] == nil {
+			return wrongTypes
+		}
+		solver.PointSymmetric(points[0], points[1], lines[2])
+	case sketcher.ConstraintEqual:
+		solver.Equal(entities[0], entities[1])
+	case sketcher.ConstraintCurveDiameter:
+		solver.CurveDiameter(entities[0], value)
+	case sketcher.ConstraintFixed:
+		solver.MakeFixed(entities[0])
+	}
+	return nil
+}
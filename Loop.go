@@ -0,0 +1,203 @@
+package makercad
+
+import (
+	"math"
+	"slices"
+
+	"github.com/marcuswu/makercad/sketcher"
+	"github.com/rs/zerolog/log"
+
+	"github.com/marcuswu/gooccwrapper/brepbuilderapi"
+)
+
+// Loop is an ordered, closed chain of sketch entities such as a glyph contour or an imported path
+type Loop []sketcher.Entity
+
+// NewFaceFromLoop creates a face bounded by a single loop. Construction entities and entities which do not create an edge are ignored.
+func NewFaceFromLoop(loop Loop) *Face {
+	brepbuilderapi.SetPrecision(0.0001)
+	combined := brepbuilderapi.NewMakeWire()
+	edgeCount := 0
+	for _, entity := range loop {
+		if entity.IsConstruction() {
+			continue
+		}
+		edge := entity.MakeEdge()
+		if edge == nil {
+			continue
+		}
+		combined.AddEdge(edge.Edge)
+		edgeCount++
+	}
+	if edgeCount == 0 {
+		return nil
+	}
+
+	log.Debug().Int("edge count", edgeCount).Msg("Making wire for loop")
+	makeFace := brepbuilderapi.NewMakeFace(combined.ToTopoDSWire())
+	return &Face{makeFace.ToTopoDSFace()}
+}
+
+// NewFacesFromLoops creates faces from a set of loops which may be nested, like the contours of the letter "o".
+// Loops nested inside an odd number of other loops are holes and are removed from the loop directly containing them.
+// Each remaining region becomes its own Face.
+func NewFacesFromLoops(loops []Loop) ListOfFace {
+	polygons := make([][][2]float64, len(loops))
+	for i, loop := range loops {
+		polygons[i] = loop.polygon()
+	}
+
+	// A loop's depth is the number of other loops containing it
+	depth := make([]int, len(loops))
+	parent := make([]int, len(loops))
+	for i := range loops {
+		parent[i] = -1
+		if len(polygons[i]) == 0 {
+			continue
+		}
+		for j := range loops {
+			if i == j || len(polygons[j]) < 3 || !polygonContains(polygons[j], polygons[i][0]) {
+				continue
+			}
+			depth[i]++
+			// The direct parent is the smallest containing loop
+			if parent[i] < 0 || math.Abs(polygonArea(polygons[j])) < math.Abs(polygonArea(polygons[parent[i]])) {
+				parent[i] = j
+			}
+		}
+	}
+
+	holes := make(map[int]ListOfShape)
+	for i := range loops {
+		if depth[i]%2 == 1 && parent[i] >= 0 {
+			hole := NewFaceFromLoop(loops[i])
+			if hole != nil {
+				holes[parent[i]] = append(holes[parent[i]], *hole.AsShape())
+			}
+		}
+	}
+
+	faces := make(ListOfFace, 0, len(loops))
+	for i := range loops {
+		if depth[i]%2 == 1 {
+			continue
+		}
+		face := NewFaceFromLoop(loops[i])
+		if face == nil {
+			continue
+		}
+		if len(holes[i]) < 1 {
+			faces = append(faces, face)
+			continue
+		}
+		op, err := face.AsShape().Remove(holes[i])
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to remove holes from loop face")
+			continue
+		}
+		faces = append(faces, op.Shape().Faces()...)
+	}
+
+	return faces
+}
+
+// NewFaces creates faces from a sketch holding several separate closed outlines, such as [Sketch.Text] or an imported
+// drawing. Connected entities form a loop and the loops become faces as in [NewFacesFromLoops]. Construction entities
+// are ignored.
+func NewFaces(s *Sketch) ListOfFace {
+	return NewFacesFromLoops(sketchLoops(s.solver.Entities()))
+}
+
+// sketchLoops groups entities into chains of connected entities, following each chain from entity to entity
+func sketchLoops(entities []sketcher.Entity) []Loop {
+	remaining := make([]sketcher.Entity, 0, len(entities))
+	for _, entity := range entities {
+		if !entity.IsConstruction() {
+			remaining = append(remaining, entity)
+		}
+	}
+
+	loops := make([]Loop, 0)
+	for len(remaining) > 0 {
+		loop := Loop{remaining[0]}
+		remaining = remaining[1:]
+		for {
+			next := slices.IndexFunc(remaining, loop[len(loop)-1].IsConnectedTo)
+			if next < 0 {
+				break
+			}
+			loop = append(loop, remaining[next])
+			remaining = slices.Delete(remaining, next, next+1)
+		}
+		loops = append(loops, loop)
+	}
+	return loops
+}
+
+// polygon approximates the loop as a list of sketch coordinates. Arcs and circles are sampled.
+func (l Loop) polygon() [][2]float64 {
+	const curveSamples = 16
+	points := make([][2]float64, 0, len(l))
+	for _, entity := range l {
+		switch e := entity.(type) {
+		case *sketcher.Line:
+			start, end := [2]float64{e.Start.X, e.Start.Y}, [2]float64{e.End.X, e.End.Y}
+			// Follow the loop even if an entity was drawn in the opposite direction
+			if len(points) > 0 && distance2D(points[len(points)-1], end) < distance2D(points[len(points)-1], start) {
+				start, end = end, start
+			}
+			points = append(points, start, end)
+		case *sketcher.Arc:
+			radius := math.Hypot(e.Start.X-e.Center.X, e.Start.Y-e.Center.Y)
+			startAngle := math.Atan2(e.Start.Y-e.Center.Y, e.Start.X-e.Center.X)
+			endAngle := math.Atan2(e.End.Y-e.Center.Y, e.End.X-e.Center.X)
+			if endAngle <= startAngle {
+				endAngle += 2 * math.Pi
+			}
+			arcPoints := make([][2]float64, 0, curveSamples+1)
+			for i := 0; i <= curveSamples; i++ {
+				angle := startAngle + (endAngle-startAngle)*float64(i)/curveSamples
+				arcPoints = append(arcPoints, [2]float64{e.Center.X + radius*math.Cos(angle), e.Center.Y + radius*math.Sin(angle)})
+			}
+			if len(points) > 0 && distance2D(points[len(points)-1], arcPoints[curveSamples]) < distance2D(points[len(points)-1], arcPoints[0]) {
+				for i, j := 0, curveSamples; i < j; i, j = i+1, j-1 {
+					arcPoints[i], arcPoints[j] = arcPoints[j], arcPoints[i]
+				}
+			}
+			points = append(points, arcPoints...)
+		case *sketcher.Circle:
+			for i := 0; i < curveSamples; i++ {
+				angle := 2 * math.Pi * float64(i) / curveSamples
+				points = append(points, [2]float64{e.Center.X + e.Radius*math.Cos(angle), e.Center.Y + e.Radius*math.Sin(angle)})
+			}
+		}
+	}
+	return points
+}
+
+func distance2D(a, b [2]float64) float64 {
+	return math.Hypot(a[0]-b[0], a[1]-b[1])
+}
+
+// polygonArea returns the signed area of the polygon (positive when counterclockwise)
+func polygonArea(polygon [][2]float64) float64 {
+	area := 0.0
+	for i := range polygon {
+		j := (i + 1) % len(polygon)
+		area += polygon[i][0]*polygon[j][1] - polygon[j][0]*polygon[i][1]
+	}
+	return area / 2
+}
+
+// polygonContains returns whether the point is inside the polygon using the even-odd rule
+func polygonContains(polygon [][2]float64, point [2]float64) bool {
+	inside := false
+	for i, j := 0, len(polygon)-1; i < len(polygon); j, i = i, i+1 {
+		a, b := polygon[i], polygon[j]
+		if (a[1] > point[1]) != (b[1] > point[1]) &&
+			point[0] < (b[0]-a[0])*(point[1]-a[1])/(b[1]-a[1])+a[0] {
+			inside = !inside
+		}
+	}
+	return inside
+}
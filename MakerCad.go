@@ -6,7 +6,6 @@ import (
 	"github.com/marcuswu/makercad/sketcher"
 
 	"github.com/marcuswu/gooccwrapper/brep"
-	"github.com/marcuswu/gooccwrapper/brepalgoapi"
 	"github.com/marcuswu/gooccwrapper/brepfilletapi"
 	"github.com/marcuswu/gooccwrapper/brepmesh"
 	"github.com/marcuswu/gooccwrapper/brepprimapi"
@@ -14,7 +13,6 @@ import (
 	"github.com/marcuswu/gooccwrapper/stepcontrol"
 	"github.com/marcuswu/gooccwrapper/stlapi"
 	"github.com/marcuswu/gooccwrapper/topods"
-	"github.com/marcuswu/gooccwrapper/toptools"
 )
 
 type ExportQuality int
@@ -28,8 +26,8 @@ const (
 
 const StepExportSuccess = 1
 
-// MakerCad contains the origin planes (there's probably a better mathematical term) and all sketches created with an instance.
-// Create an instance with [NewMakerCad] to ensure the planes are initialized.
+// MakerCad contains the origin planes (there's probably a better mathematical term), the global axes and all sketches created with an instance.
+// Create an instance with [NewMakerCad] to ensure the planes and axes are initialized.
 type MakerCad struct {
 	sketches    []*Sketch
 	FrontPlane  *sketcher.PlaneParameters
@@ -38,9 +36,12 @@ type MakerCad struct {
 	BottomPlane *sketcher.PlaneParameters
 	LeftPlane   *sketcher.PlaneParameters
 	RightPlane  *sketcher.PlaneParameters
+	XAxis       *sketcher.Axis
+	YAxis       *sketcher.Axis
+	ZAxis       *sketcher.Axis
 }
 
-// NewMakerCad Creates a MakerCad instance and initializes the predefined planes
+// NewMakerCad Creates a MakerCad instance and initializes the predefined planes and axes
 func NewMakerCad() *MakerCad {
 	return &MakerCad{
 		sketches: make([]*Sketch, 0),
@@ -74,12 +75,15 @@ func NewMakerCad() *MakerCad {
 			sketcher.NewVectorFromValues(1, 0, 0),
 			sketcher.NewVectorFromValues(0, 1, 0),
 		),
+		XAxis: sketcher.NewAxis(sketcher.NewVectorFromValues(0, 0, 0), sketcher.NewVectorFromValues(1, 0, 0)),
+		YAxis: sketcher.NewAxis(sketcher.NewVectorFromValues(0, 0, 0), sketcher.NewVectorFromValues(0, 1, 0)),
+		ZAxis: sketcher.NewAxis(sketcher.NewVectorFromValues(0, 0, 0), sketcher.NewVectorFromValues(0, 0, 1)),
 	}
 }
 
 // Sketch creates a new sketch on the provided plane.
 func (m *MakerCad) Sketch(planer sketcher.Planer) *Sketch {
-	sketch := &Sketch{sketcher.NewDlineateSolver(planer)}
+	sketch := newSketch(planer)
 	m.sketches = append(m.sketches, sketch)
 	return sketch
 }
@@ -88,14 +92,6 @@ func (m *MakerCad) Sketch(planer sketcher.Planer) *Sketch {
 func (*MakerCad) ExportStl(filename string, shapes ListOfShape, quality ExportQuality) error {
 	linear := 0.01
 	angular := 0.1
-	compound := topods.NewCompound()
-	builder := brep.NewBuilder()
-	builder.MakeCompound(compound)
-	for i := range shapes {
-		builder.Add(compound, shapes[i].Shape)
-	}
-
-	stlWriter := stlapi.NewWriter()
 	switch quality {
 	case QualityVeryLow:
 		linear = 0.5
@@ -111,7 +107,21 @@ func (*MakerCad) ExportStl(filename string, shapes ListOfShape, quality ExportQu
 		angular = 0.08
 	}
 
-	_ = brepmesh.NewIncrementalMesh(compound, linear, false, angular, true)
+	return writeStl(filename, shapes, linear, false, angular)
+}
+
+// writeStl triangulates the shapes with the linear and angular deflection and writes the triangles to an STL file.
+// A relative linear deflection is a fraction of the size of each edge and face rather than a distance.
+func writeStl(filename string, shapes ListOfShape, linear float64, relative bool, angular float64) error {
+	compound := topods.NewCompound()
+	builder := brep.NewBuilder()
+	builder.MakeCompound(compound)
+	for i := range shapes {
+		builder.Add(compound, shapes[i].Shape)
+	}
+
+	stlWriter := stlapi.NewWriter()
+	_ = brepmesh.NewIncrementalMesh(compound, linear, relative, angular, true)
 	if !stlWriter.Write(compound, filename) {
 		return errors.New("Failed to write STL")
 	}
@@ -154,37 +164,22 @@ func (*MakerCad) MakeCylinder(plane sketcher.Planer, radius, height float64) Sha
 
 // Combine performs a boolean union of the target and the provided tools
 func (*MakerCad) Combine(target Shape, tools ListOfShape) (*CadOperation, error) {
-	operation := brepalgoapi.NewFuse().ToBooleanOperation()
-	arguments := toptools.NewListOfShape()
-	arguments.Append(target.Shape)
-
-	operation.SetTools(tools.ToCascadeList())
-	operation.SetArguments(arguments)
-	operation.Build()
-
-	return NewCadOperation(tools, &operation), nil
+	return target.Combine(tools)
 }
 
 // Remove performs a boolean difference from the target with the provided tools
 func (*MakerCad) Remove(target Shape, tools ListOfShape) (*CadOperation, error) {
-	operation := brepalgoapi.NewCut().ToBooleanOperation()
-	arguments := toptools.NewListOfShape()
-	arguments.Append(target.Shape)
-
-	operation.SetTools(tools.ToCascadeList())
-	operation.SetArguments(arguments)
-	operation.Build()
-
-	return NewCadOperation(tools, &operation), nil
+	return target.Remove(tools)
 }
 
-// Chamfer performs a 45 degree Chamfer of the supplied shape and edges to the specified depth
+// Chamfer performs an equal distance Chamfer of the supplied shape and edges, set back from each edge by the specified
+// depth on both faces
 func (*MakerCad) Chamfer(target Shape, edges sketcher.ListOfEdge, depth float64) (Shape, error) {
-	fillet := brepfilletapi.NewMakeChamfer(topods.TopoDSShape(target.Shape.Shape))
+	chamfer := brepfilletapi.NewMakeChamfer(topods.TopoDSShape(target.Shape.Shape))
 	for _, e := range edges {
-		fillet.AddEdge(topods.TopoDSEdge(e.Edge.Edge), depth)
+		chamfer.AddEdge(topods.TopoDSEdge(e.Edge.Edge), depth)
 	}
-	return Shape{fillet.Shape()}, nil
+	return Shape{chamfer.Shape()}, nil
 }
 
 // Fillet performs a spherical Fillet of the supplied shape and edges to the specified radius
@@ -195,3 +190,28 @@ func (*MakerCad) Fillet(target Shape, edges sketcher.ListOfEdge, radius float64)
 	}
 	return Shape{fillet.Shape()}, nil
 }
+
+// ChamferEdges performs an equal distance Chamfer of the supplied shape with a separate depth for each edge (see
+// [MakerCad.Chamfer])
+func (*MakerCad) ChamferEdges(target Shape, edges sketcher.ListOfEdge, depths []float64) (Shape, error) {
+	if len(depths) != len(edges) {
+		return Shape{}, errors.New("a chamfer depth is required for each edge")
+	}
+	chamfer := brepfilletapi.NewMakeChamfer(topods.TopoDSShape(target.Shape.Shape))
+	for i, e := range edges {
+		chamfer.AddEdge(topods.TopoDSEdge(e.Edge.Edge), depths[i])
+	}
+	return Shape{chamfer.Shape()}, nil
+}
+
+// FilletEdges performs a spherical Fillet of the supplied shape with a separate radius for each edge
+func (*MakerCad) FilletEdges(target Shape, edges sketcher.ListOfEdge, radii []float64) (Shape, error) {
+	if len(radii) != len(edges) {
+		return Shape{}, errors.New("a fillet radius is required for each edge")
+	}
+	fillet := brepfilletapi.NewMakeFillet(topods.TopoDSShape(target.Shape.Shape))
+	for i, e := range edges {
+		fillet.AddEdge(topods.TopoDSEdge(e.Edge.Edge), radii[i])
+	}
+	return Shape{fillet.Shape()}, nil
+}
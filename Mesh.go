@@ -0,0 +1,75 @@
+package makercad
+
+import (
+	"os"
+
+	"github.com/marcuswu/makercad/mesh"
+	"github.com/marcuswu/makercad/sketcher"
+
+	"github.com/marcuswu/gooccwrapper/brepgprop"
+	"github.com/marcuswu/gooccwrapper/gp"
+	"github.com/marcuswu/gooccwrapper/gprop"
+)
+
+// triangulate returns a triangle mesh of the shapes with the angular deflection and a linear deflection relative to the
+// size of each edge and face.
+// Meshing stores the triangulation on the shapes meshed, so a copy of the shapes is meshed instead. Reflecting a shape
+// twice copies its geometry where a rigid transform would share it. The copy is centered on the origin to keep the
+// precision of the text coordinates the wrapper's STL writer produces, which is its only access to triangulations.
+func triangulate(shapes ListOfShape, linear float64, angular float64) (*mesh.Mesh, error) {
+	center := mesh.Vector{}
+	area := 0.0
+	for _, shape := range shapes {
+		props := gprop.NewGProps()
+		brepgprop.SurfaceProperties(shape.Shape, props, false, false)
+		center = center.Add(meshPoint(props.CenterOfMass()).Scale(props.Mass()))
+		area += props.Mass()
+	}
+	if area > 0 {
+		center = center.Scale(1 / area)
+	}
+
+	mirror := sketcher.NewPlaneParameters()
+	copies := make(ListOfShape, len(shapes))
+	for i, shape := range shapes {
+		copies[i] = shape.Mirrored(mirror).Mirrored(mirror).Translated(-center.X, -center.Y, -center.Z)
+	}
+
+	triangles, err := readStl(copies, linear, angular)
+	if err != nil {
+		return nil, err
+	}
+	for i := range triangles.Triangles {
+		for j := range triangles.Triangles[i] {
+			triangles.Triangles[i][j] = triangles.Triangles[i][j].Add(center)
+		}
+	}
+	return triangles, nil
+}
+
+// readStl meshes the shapes into a temporary STL file and reads the mesh back
+func readStl(shapes ListOfShape, linear float64, angular float64) (*mesh.Mesh, error) {
+	file, err := os.CreateTemp("", "makercad-*.stl")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(file.Name())
+	if err := file.Close(); err != nil {
+		return nil, err
+	}
+
+	if err := writeStl(file.Name(), shapes, linear, true, angular); err != nil {
+		return nil, err
+	}
+	file, err = os.Open(file.Name())
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return mesh.ReadSTL(file)
+}
+
+// meshPoint converts a point to a mesh vector
+func meshPoint(p gp.Pnt) mesh.Vector {
+	return mesh.Vector{X: p.X(), Y: p.Y(), Z: p.Z()}
+}
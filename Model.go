@@ -0,0 +1,373 @@
+package makercad
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/marcuswu/makercad/sketcher"
+
+	"github.com/marcuswu/gooccwrapper/gp"
+)
+
+// FeatureType identifies the kind of operation a [Feature] performs
+type FeatureType int
+
+const (
+	FeatureSketch FeatureType = iota
+	FeatureExtrude
+	FeatureRevolve
+	FeatureBoolean
+	FeatureFillet
+	FeatureChamfer
+	FeatureCustom
+)
+
+func (t FeatureType) String() string {
+	switch t {
+	case FeatureSketch:
+		return "Sketch"
+	case FeatureExtrude:
+		return "Extrude"
+	case FeatureRevolve:
+		return "Revolve"
+	case FeatureBoolean:
+		return "Boolean"
+	case FeatureFillet:
+		return "Fillet"
+	case FeatureChamfer:
+		return "Chamfer"
+	case FeatureCustom:
+		return "Custom"
+	}
+	return "Unknown"
+}
+
+// Params holds the named numeric parameters of a feature
+type Params map[string]float64
+
+// FeatureResult is what a feature builds: a sketch, a shape or both
+type FeatureResult struct {
+	Sketch *Sketch
+	Shape  *Shape
+	// names holds the faces of Shape by persistent name
+	names map[string]ListOfFace
+}
+
+// BuildFunc builds a feature from its parameters and the results of its dependencies, in the order the dependencies were listed
+type BuildFunc func(params Params, inputs []FeatureResult) (FeatureResult, error)
+
+// Feature is a step in a [Model]. Its parameters can be changed and the model rebuilt.
+type Feature struct {
+	Name         string
+	Type         FeatureType
+	Params       Params
+	Dependencies []string
+	Suppressed   bool
+	build        BuildFunc
+	result       FeatureResult
+	dirty        bool
+}
+
+// Result returns what the feature built during the last rebuild
+func (f *Feature) Result() FeatureResult {
+	return f.result
+}
+
+// Model records the features used to build a part as a dependency graph so the part can be rebuilt after a parameter
+// changes or a feature is suppressed. Features are built in the order they were added; a feature can only depend on
+// features added before it.
+type Model struct {
+	cad      *MakerCad
+	features []*Feature
+}
+
+// NewModel creates an empty model using the provided MakerCad instance
+func NewModel(cad *MakerCad) *Model {
+	return &Model{cad: cad, features: make([]*Feature, 0)}
+}
+
+// Features returns the features of the model in build order
+func (m *Model) Features() []*Feature {
+	return m.features
+}
+
+// Feature returns the named feature or nil if there is none
+func (m *Model) Feature(name string) *Feature {
+	index := slices.IndexFunc(m.features, func(f *Feature) bool { return f.Name == name })
+	if index < 0 {
+		return nil
+	}
+	return m.features[index]
+}
+
+// Add adds a feature built by a custom function. Use this for operations without a dedicated Add method.
+func (m *Model) Add(name string, featureType FeatureType, params Params, dependencies []string, build BuildFunc) (*Feature, error) {
+	if name == "" {
+		return nil, errors.New("feature name must not be empty")
+	}
+	if m.Feature(name) != nil {
+		return nil, fmt.Errorf("feature %q already exists", name)
+	}
+	for _, dependency := range dependencies {
+		if m.Feature(dependency) == nil {
+			return nil, fmt.Errorf("feature %q depends on unknown feature %q", name, dependency)
+		}
+	}
+	if params == nil {
+		params = Params{}
+	}
+
+	feature := &Feature{
+		Name:         name,
+		Type:         featureType,
+		Params:       params,
+		Dependencies: slices.Clone(dependencies),
+		build:        build,
+		dirty:        true,
+	}
+	m.features = append(m.features, feature)
+	return feature, nil
+}
+
+// AddSketch adds a sketch on the plane drawn by the draw function. The sketch is solved after drawing.
+func (m *Model) AddSketch(name string, plane sketcher.Planer, params Params, draw func(sketch *Sketch, params Params) error) (*Feature, error) {
+	return m.Add(name, FeatureSketch, params, nil, func(params Params, _ []FeatureResult) (FeatureResult, error) {
+		sketch := newSketch(plane)
+		if err := draw(sketch, params); err != nil {
+			return FeatureResult{}, err
+		}
+		if err := sketch.Solve(); err != nil {
+			return FeatureResult{}, err
+		}
+		return FeatureResult{Sketch: sketch}, nil
+	})
+}
+
+// AddExtrude adds an extrusion of a sketch feature by the "distance" parameter
+func (m *Model) AddExtrude(name string, sketch string, distance float64) (*Feature, error) {
+	return m.Add(name, FeatureExtrude, Params{"distance": distance}, []string{sketch}, func(params Params, inputs []FeatureResult) (FeatureResult, error) {
+		if inputs[0].Sketch == nil {
+			return FeatureResult{}, fmt.Errorf("%q is not a sketch", sketch)
+		}
+		face := NewFace(inputs[0].Sketch)
+		operation, err := face.Extrude(params["distance"])
+		if err != nil {
+			return FeatureResult{}, err
+		}
+		shape := operation.Shape()
+		vector := gp.NewVecDir(face.Normal()).Multiplied(params["distance"])
+		return FeatureResult{Shape: &shape, names: extrudeFaceNames(name, inputs[0].Sketch, vector, shape)}, nil
+	})
+}
+
+// AddRevolve adds a revolution of a sketch feature around an axis chosen from the sketch by the "angle" parameter in radians
+func (m *Model) AddRevolve(name string, sketch string, axis func(sketch *Sketch) *sketcher.Line, angle float64) (*Feature, error) {
+	return m.Add(name, FeatureRevolve, Params{"angle": angle}, []string{sketch}, func(params Params, inputs []FeatureResult) (FeatureResult, error) {
+		if inputs[0].Sketch == nil {
+			return FeatureResult{}, fmt.Errorf("%q is not a sketch", sketch)
+		}
+		operation, err := NewFace(inputs[0].Sketch).Revolve(axis(inputs[0].Sketch), params["angle"])
+		if err != nil {
+			return FeatureResult{}, err
+		}
+		shape := operation.Shape()
+		return FeatureResult{Shape: &shape}, nil
+	})
+}
+
+// AddBoolean adds a union (MergeTypeAdd) or difference (MergeTypeRemove) of the tool features with the target feature.
+// Tools without a shape, such as suppressed extrusions, are left out.
+func (m *Model) AddBoolean(name string, merge MergeType, target string, tools ...string) (*Feature, error) {
+	if merge != MergeTypeAdd && merge != MergeTypeRemove {
+		return nil, errors.New("boolean features must add or remove")
+	}
+	dependencies := append([]string{target}, tools...)
+	return m.Add(name, FeatureBoolean, nil, dependencies, func(_ Params, inputs []FeatureResult) (FeatureResult, error) {
+		if inputs[0].Shape == nil {
+			return FeatureResult{}, fmt.Errorf("%q has no shape", target)
+		}
+		toolShapes := make(ListOfShape, 0, len(tools))
+		for _, input := range inputs[1:] {
+			if input.Shape != nil {
+				toolShapes = append(toolShapes, *input.Shape)
+			}
+		}
+		if len(toolShapes) == 0 {
+			return inputs[0], nil
+		}
+
+		var operation *CadOperation
+		var err error
+		if merge == MergeTypeAdd {
+			operation, err = inputs[0].Shape.Combine(toolShapes)
+		} else {
+			operation, err = inputs[0].Shape.Remove(toolShapes)
+		}
+		if err != nil {
+			return FeatureResult{}, err
+		}
+		shape := operation.Shape()
+		return FeatureResult{Shape: &shape}, nil
+	})
+}
+
+// AddFillet adds a fillet with the "radius" parameter of the edges chosen from the target feature's shape
+func (m *Model) AddFillet(name string, target string, radius float64, edges func(shape Shape) sketcher.ListOfEdge) (*Feature, error) {
+	return m.Add(name, FeatureFillet, Params{"radius": radius}, []string{target}, func(params Params, inputs []FeatureResult) (FeatureResult, error) {
+		if inputs[0].Shape == nil {
+			return FeatureResult{}, fmt.Errorf("%q has no shape", target)
+		}
+		shape, err := m.cad.Fillet(*inputs[0].Shape, edges(*inputs[0].Shape), params["radius"])
+		if err != nil {
+			return FeatureResult{}, err
+		}
+		return FeatureResult{Shape: &shape}, nil
+	})
+}
+
+// AddChamfer adds a chamfer with the "distance" parameter of the edges chosen from the target feature's shape
+func (m *Model) AddChamfer(name string, target string, distance float64, edges func(shape Shape) sketcher.ListOfEdge) (*Feature, error) {
+	return m.Add(name, FeatureChamfer, Params{"distance": distance}, []string{target}, func(params Params, inputs []FeatureResult) (FeatureResult, error) {
+		if inputs[0].Shape == nil {
+			return FeatureResult{}, fmt.Errorf("%q has no shape", target)
+		}
+		shape, err := m.cad.Chamfer(*inputs[0].Shape, edges(*inputs[0].Shape), params["distance"])
+		if err != nil {
+			return FeatureResult{}, err
+		}
+		return FeatureResult{Shape: &shape}, nil
+	})
+}
+
+// SetParam changes an existing parameter of a feature. The feature and everything depending on it are rebuilt by the next [Model.Rebuild].
+func (m *Model) SetParam(feature string, param string, value float64) error {
+	f := m.Feature(feature)
+	if f == nil {
+		return fmt.Errorf("unknown feature %q", feature)
+	}
+	if _, ok := f.Params[param]; !ok {
+		return fmt.Errorf("feature %q has no parameter %q", feature, param)
+	}
+	f.Params[param] = value
+	f.dirty = true
+	return nil
+}
+
+// Suppress turns a feature off or back on. A suppressed feature passes the result of its first dependency through
+// unchanged, so suppressing a fillet leaves the unfilleted shape and suppressing a tool of a boolean leaves it out.
+func (m *Model) Suppress(feature string, suppressed bool) error {
+	f := m.Feature(feature)
+	if f == nil {
+		return fmt.Errorf("unknown feature %q", feature)
+	}
+	if f.Suppressed != suppressed {
+		f.Suppressed = suppressed
+		f.dirty = true
+	}
+	return nil
+}
+
+// Rebuild builds every feature which changed since the last rebuild along with the features depending on it
+func (m *Model) Rebuild() error {
+	rebuilt := make(map[string]bool)
+	for _, f := range m.features {
+		needsBuild := f.dirty
+		inputs := make([]FeatureResult, 0, len(f.Dependencies))
+		for _, dependency := range f.Dependencies {
+			needsBuild = needsBuild || rebuilt[dependency]
+			inputs = append(inputs, m.Feature(dependency).result)
+		}
+		if !needsBuild {
+			continue
+		}
+
+		if f.Suppressed {
+			f.result = FeatureResult{}
+			if len(inputs) > 0 {
+				f.result = inputs[0]
+			}
+		} else {
+			result, err := f.build(f.Params, inputs)
+			if err != nil {
+				return fmt.Errorf("feature %q: %w", f.Name, err)
+			}
+			result.names = inheritFaceNames(result, inputs)
+			f.result = result
+		}
+		f.dirty = false
+		rebuilt[f.Name] = true
+	}
+	return nil
+}
+
+// inheritFaceNames adds the names of the input shapes' faces which remain in the result to the result's own names
+func inheritFaceNames(result FeatureResult, inputs []FeatureResult) map[string]ListOfFace {
+	names := make(map[string]ListOfFace, len(result.names))
+	for name, faces := range result.names {
+		names[name] = faces
+	}
+	if result.Shape == nil {
+		return names
+	}
+	for _, input := range inputs {
+		if len(input.names) == 0 {
+			continue
+		}
+		for name, faces := range trackFaces(input.names, *result.Shape) {
+			if _, ok := names[name]; !ok {
+				names[name] = faces
+			}
+		}
+	}
+	return names
+}
+
+// Shape returns the shape built by a feature in the last rebuild
+func (m *Model) Shape(feature string) (Shape, error) {
+	f := m.Feature(feature)
+	if f == nil {
+		return Shape{}, fmt.Errorf("unknown feature %q", feature)
+	}
+	if f.result.Shape == nil {
+		return Shape{}, fmt.Errorf("feature %q has not built a shape", feature)
+	}
+	return *f.result.Shape, nil
+}
+
+// Sketch returns the sketch built by a feature in the last rebuild
+func (m *Model) Sketch(feature string) (*Sketch, error) {
+	f := m.Feature(feature)
+	if f == nil {
+		return nil, fmt.Errorf("unknown feature %q", feature)
+	}
+	if f.result.Sketch == nil {
+		return nil, fmt.Errorf("feature %q has not built a sketch", feature)
+	}
+	return f.result.Sketch, nil
+}
+
+// Tree lists the features in build order with their type, parameters and dependencies, one per line
+func (m *Model) Tree() string {
+	var tree strings.Builder
+	for _, f := range m.features {
+		fmt.Fprintf(&tree, "%s [%s]", f.Name, f.Type)
+		names := make([]string, 0, len(f.Params))
+		for param := range f.Params {
+			names = append(names, param)
+		}
+		slices.Sort(names)
+		for _, param := range names {
+			fmt.Fprintf(&tree, " %s=%g", param, f.Params[param])
+		}
+		if f.Suppressed {
+			tree.WriteString(" (suppressed)")
+		}
+		if len(f.Dependencies) > 0 {
+			fmt.Fprintf(&tree, " <- %s", strings.Join(f.Dependencies, ", "))
+		}
+		tree.WriteByte('\n')
+	}
+	return tree.String()
+}
@@ -0,0 +1,133 @@
+package makercad
+
+import (
+	"testing"
+)
+
+// countingModel returns a model of custom features which record how many times each was built. Each feature's shape
+// is nil; its result carries no geometry so the tests only exercise the dependency graph.
+func countingModel(t *testing.T) (*Model, map[string]int) {
+	t.Helper()
+	builds := make(map[string]int)
+	model := NewModel(NewMakerCad())
+	add := func(name string, params Params, dependencies ...string) {
+		_, err := model.Add(name, FeatureCustom, params, dependencies, func(Params, []FeatureResult) (FeatureResult, error) {
+			builds[name]++
+			return FeatureResult{}, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	add("base", Params{"size": 1})
+	add("side", Params{"size": 2})
+	add("body", nil, "base")
+	add("joined", nil, "body", "side")
+	return model, builds
+}
+
+func TestModelRebuild(t *testing.T) {
+	model, builds := countingModel(t)
+	if err := model.Rebuild(); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"base", "side", "body", "joined"} {
+		if builds[name] != 1 {
+			t.Errorf("%s built %d times on the first rebuild, want 1", name, builds[name])
+		}
+	}
+
+	if err := model.Rebuild(); err != nil {
+		t.Fatal(err)
+	}
+	if builds["base"] != 1 || builds["joined"] != 1 {
+		t.Errorf("an unchanged model was rebuilt: %v", builds)
+	}
+
+	if err := model.SetParam("side", "size", 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := model.Rebuild(); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]int{"base": 1, "body": 1, "side": 2, "joined": 2}
+	for name, count := range want {
+		if builds[name] != count {
+			t.Errorf("%s built %d times after changing side, want %d", name, builds[name], count)
+		}
+	}
+}
+
+func TestModelSuppress(t *testing.T) {
+	model, builds := countingModel(t)
+	if err := model.Rebuild(); err != nil {
+		t.Fatal(err)
+	}
+	if err := model.Suppress("body", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := model.Rebuild(); err != nil {
+		t.Fatal(err)
+	}
+	if builds["body"] != 1 || builds["joined"] != 2 {
+		t.Errorf("suppressing body should rebuild only its dependents: %v", builds)
+	}
+
+	// Suppressing a feature that is already suppressed changes nothing
+	if err := model.Suppress("body", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := model.Rebuild(); err != nil {
+		t.Fatal(err)
+	}
+	if builds["joined"] != 2 {
+		t.Errorf("suppressing body again rebuilt joined: %v", builds)
+	}
+
+	if err := model.Suppress("body", false); err != nil {
+		t.Fatal(err)
+	}
+	if err := model.Rebuild(); err != nil {
+		t.Fatal(err)
+	}
+	if builds["body"] != 2 || builds["joined"] != 3 {
+		t.Errorf("unsuppressing body should rebuild it and its dependents: %v", builds)
+	}
+}
+
+func TestModelErrors(t *testing.T) {
+	model, _ := countingModel(t)
+	build := func(Params, []FeatureResult) (FeatureResult, error) { return FeatureResult{}, nil }
+	if _, err := model.Add("base", FeatureCustom, nil, nil, build); err == nil {
+		t.Error("adding a duplicate feature should fail")
+	}
+	if _, err := model.Add("", FeatureCustom, nil, nil, build); err == nil {
+		t.Error("adding an unnamed feature should fail")
+	}
+	if _, err := model.Add("later", FeatureCustom, nil, []string{"missing"}, build); err == nil {
+		t.Error("depending on an unknown feature should fail")
+	}
+	if err := model.SetParam("base", "missing", 1); err == nil {
+		t.Error("setting an unknown parameter should fail")
+	}
+	if err := model.SetParam("missing", "size", 1); err == nil {
+		t.Error("setting a parameter of an unknown feature should fail")
+	}
+	if err := model.Suppress("missing", true); err == nil {
+		t.Error("suppressing an unknown feature should fail")
+	}
+	if _, err := model.Shape("base"); err == nil {
+		t.Error("a feature without a shape should fail")
+	}
+}
+
+func TestModelTree(t *testing.T) {
+	model, _ := countingModel(t)
+	if err := model.Suppress("body", true); err != nil {
+		t.Fatal(err)
+	}
+	want := "base [Custom] size=1\nside [Custom] size=2\nbody [Custom] (suppressed) <- base\njoined [Custom] <- body, side\n"
+	if tree := model.Tree(); tree != want {
+		t.Errorf("got\n%s\nwant\n%s", tree, want)
+	}
+}
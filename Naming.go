@@ -0,0 +1,277 @@
+package makercad
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/marcuswu/makercad/sketcher"
+
+	"github.com/marcuswu/gooccwrapper/brepadapter"
+	"github.com/marcuswu/gooccwrapper/brepgprop"
+	"github.com/marcuswu/gooccwrapper/gp"
+	"github.com/marcuswu/gooccwrapper/gprop"
+	"github.com/marcuswu/gooccwrapper/topods"
+)
+
+// namingTolerance is the distance below which points are considered the same when matching faces and edges
+const namingTolerance = 1e-6
+
+// faceSignature describes the geometry of a face so the face can be found again in a shape built from it
+type faceSignature struct {
+	planar      bool
+	cylindrical bool
+	conical     bool
+	center      gp.Pnt
+	normal      gp.Dir
+	area        float64
+	// circles are the circular edges of a curved face, which place the axis of a cylinder, cone or sphere
+	circles []circleSignature
+}
+
+// circleSignature places a circular edge
+type circleSignature struct {
+	center gp.Pnt
+	axis   gp.Dir
+	radius float64
+}
+
+func signatureOf(f *Face) faceSignature {
+	props := gprop.NewGProps()
+	brepgprop.SurfaceProperties(topods.NewShapeFromRef(topods.TopoDSShape(f.face.Face)), props, false, false)
+	signature := faceSignature{
+		planar:      f.IsPlanar(),
+		cylindrical: f.IsCylindrical(),
+		conical:     f.IsConical(),
+		center:      props.CenterOfMass(),
+		area:        props.Mass(),
+	}
+	if signature.planar {
+		signature.normal = f.Normal()
+		return signature
+	}
+	for _, edge := range f.Edges().IsCircle() {
+		circle := brepadapter.NewCurve(edge.Edge).ToCircle()
+		signature.circles = append(signature.circles, circleSignature{circle.Location(), edge.CircleAxis(), circle.Radius()})
+	}
+	return signature
+}
+
+// matches returns whether a face with signature o is what remains of the face with signature s after a
+// boolean or fillet. Such a face has the same kind of surface and overlaps the original. Planar faces lie in the same
+// plane and curved faces share their axis.
+func (s faceSignature) matches(o faceSignature) bool {
+	if s.planar != o.planar || s.cylindrical != o.cylindrical || s.conical != o.conical {
+		return false
+	}
+	reach := math.Sqrt(s.area) + math.Sqrt(o.area)
+	if s.center.Distance(o.center) > reach {
+		return false
+	}
+	if !s.planar {
+		return s.sharesAxis(o)
+	}
+	if !s.normal.IsEqual(o.normal) {
+		return false
+	}
+	offset := gp.NewVecPoints(s.center, o.center).Dot(gp.NewVecDir(s.normal))
+	return math.Abs(offset) < namingTolerance*max(1, reach)
+}
+
+// sharesAxis returns whether two curved faces have circular edges around the same axis, of the same radius for
+// cylinders. Free form faces without circular edges cannot be told apart this way and are matched by overlap alone.
+func (s faceSignature) sharesAxis(o faceSignature) bool {
+	if len(s.circles) == 0 || len(o.circles) == 0 {
+		return len(s.circles) == len(o.circles)
+	}
+	for _, a := range s.circles {
+		for _, b := range o.circles {
+			sameRadius := math.Abs(a.radius-b.radius) < namingTolerance*max(1, a.radius)
+			if a.isCoaxial(b) && (sameRadius || !s.cylindrical) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isCoaxial returns whether two circles lie around the same axis
+func (c circleSignature) isCoaxial(o circleSignature) bool {
+	if !c.axis.IsParallel(o.axis) {
+		return false
+	}
+	offset := gp.NewVecPoints(c.center, o.center)
+	along := offset.Dot(gp.NewVecDir(c.axis))
+	return math.Sqrt(max(0, offset.Dot(offset)-along*along)) < namingTolerance*max(1, c.radius)
+}
+
+// faceClaim is a name whose face matches a face of a later shape, at the distance between the two faces' centers
+type faceClaim struct {
+	name     string
+	distance float64
+}
+
+// trackFaces finds the faces of shape which remain of the named faces of an earlier shape. Matching is by geometry, so
+// one face can match several names, such as the coplanar tops of two extrusions. Each face keeps only the names whose
+// face was nearest to it; names tied for nearest, as when faces were fused into one, all keep the face.
+func trackFaces(names map[string]ListOfFace, shape Shape) map[string]ListOfFace {
+	faces := shape.Faces()
+	signatures := make([]faceSignature, len(faces))
+	for i, face := range faces {
+		signatures[i] = signatureOf(face)
+	}
+
+	claims := make([][]faceClaim, len(faces))
+	for name, named := range names {
+		for _, face := range named {
+			signature := signatureOf(face)
+			for i, candidate := range signatures {
+				if signature.matches(candidate) {
+					claims[i] = append(claims[i], faceClaim{name, signature.center.Distance(candidate.center)})
+				}
+			}
+		}
+	}
+
+	tracked := make(map[string]ListOfFace, len(names))
+	for i, faceClaims := range claims {
+		for _, name := range nearestClaims(faceClaims) {
+			if !slices.Contains(tracked[name], faces[i]) {
+				tracked[name] = append(tracked[name], faces[i])
+			}
+		}
+	}
+	return tracked
+}
+
+// nearestClaims returns the sorted names of the claims nearest to the face
+func nearestClaims(claims []faceClaim) []string {
+	nearest := math.Inf(1)
+	for _, claim := range claims {
+		nearest = min(nearest, claim.distance)
+	}
+	names := make([]string, 0, len(claims))
+	for _, claim := range claims {
+		if claim.distance <= nearest+namingTolerance*max(1, nearest) && !slices.Contains(names, claim.name) {
+			names = append(names, claim.name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// extrudeFaceNames names the faces of an extrusion of sketch by vector after the feature:
+// "<feature>.top" and "<feature>.bottom" for the end caps and "<feature>.side[i]" for the face swept
+// from the i-th line, arc or circle of the sketch (construction geometry is not counted).
+func extrudeFaceNames(feature string, sketch *Sketch, vector gp.Vec, shape Shape) map[string]ListOfFace {
+	names := make(map[string]ListOfFace)
+	faces := shape.Faces()
+	sides := make(ListOfFace, 0, len(faces))
+	direction := gp.NewDirVec(vector)
+	for _, face := range faces {
+		if face.IsPlanar() && face.Normal().IsParallel(direction) {
+			end := feature + ".bottom"
+			if face.Normal().Dot(direction) > 0 {
+				end = feature + ".top"
+			}
+			names[end] = append(names[end], face)
+			continue
+		}
+		sides = append(sides, face)
+	}
+
+	side := 0
+	for _, entity := range sketch.solver.Entities() {
+		if entity.IsConstruction() {
+			continue
+		}
+		switch entity.(type) {
+		case *sketcher.Line, *sketcher.Arc, *sketcher.Circle:
+		default:
+			continue
+		}
+
+		// The side face swept from the entity is bounded by the entity's own edge
+		if edge := entity.MakeEdge(); edge != nil {
+			swept := sides.FirstMatching(func(f *Face) bool { return slices.ContainsFunc(f.Edges(), edge.IsSame) })
+			if swept != nil {
+				names[fmt.Sprintf("%s.side[%d]", feature, side)] = ListOfFace{swept}
+			}
+		}
+		side++
+	}
+	return names
+}
+
+// FaceNames returns the persistent names of the faces of a feature's shape in sorted order.
+// Names are given by the feature that created a face, such as "extrude1.top" or "extrude1.side[3]", and follow the face
+// through later booleans, fillets and chamfers so they keep referring to the same face after parameters change.
+// Faces are followed by their geometry rather than by the history of each operation, so names are a heuristic: a face which
+// is split keeps its name on every part, a face which is removed loses it and a face matching several named faces, such
+// as one of two coplanar tops, keeps the name of the nearest.
+func (m *Model) FaceNames(feature string) ([]string, error) {
+	f := m.Feature(feature)
+	if f == nil {
+		return nil, fmt.Errorf("unknown feature %q", feature)
+	}
+	names := make([]string, 0, len(f.result.names))
+	for name := range f.result.names {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// NamedFaces returns the faces of a feature's shape with a persistent name (see [Model.FaceNames])
+func (m *Model) NamedFaces(feature string, name string) (ListOfFace, error) {
+	f := m.Feature(feature)
+	if f == nil {
+		return nil, fmt.Errorf("unknown feature %q", feature)
+	}
+	faces, ok := f.result.names[name]
+	if !ok {
+		return nil, fmt.Errorf("feature %q has no face named %q", feature, name)
+	}
+	return faces, nil
+}
+
+// EdgeNames returns the persistent names of the edges of a feature's shape in sorted order. Each edge is named after the
+// two named faces meeting at it, such as "extrude1.side[0]/extrude1.top", so it follows those faces through later features.
+func (m *Model) EdgeNames(feature string) ([]string, error) {
+	faceNames, err := m.FaceNames(feature)
+	if err != nil {
+		return nil, err
+	}
+	faces := m.Feature(feature).result.names
+	names := make([]string, 0)
+	for i, first := range faceNames {
+		for _, second := range faceNames[i+1:] {
+			if len(faces[first].SharedEdges(faces[second])) > 0 {
+				names = append(names, first+"/"+second)
+			}
+		}
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// NamedEdges returns edges of a feature's shape by persistent name. An edge name from [Model.EdgeNames], two face names
+// joined by a slash such as "extrude1.top/extrude1.side[0]", returns the edges the two faces share. A single face name
+// such as "extrude1.top" returns the edges bounding the named faces.
+func (m *Model) NamedEdges(feature string, name string) (sketcher.ListOfEdge, error) {
+	first, second, shared := strings.Cut(name, "/")
+	firstFaces, err := m.NamedFaces(feature, first)
+	if err != nil {
+		return nil, err
+	}
+	if !shared {
+		return firstFaces.Edges(), nil
+	}
+	secondFaces, err := m.NamedFaces(feature, second)
+	if err != nil {
+		return nil, err
+	}
+
+	return firstFaces.SharedEdges(secondFaces), nil
+}
@@ -0,0 +1,57 @@
+package makercad
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestNearestClaims(t *testing.T) {
+	tests := []struct {
+		name   string
+		claims []faceClaim
+		want   []string
+	}{
+		{"none", nil, []string{}},
+		{"single", []faceClaim{{"a.top", 3}}, []string{"a.top"}},
+		{"nearest", []faceClaim{{"b.top", 20}, {"a.top", 0}}, []string{"a.top"}},
+		{"tied", []faceClaim{{"b.top", 5}, {"a.top", 5}, {"c.top", 6}}, []string{"a.top", "b.top"}},
+		{"repeated name", []faceClaim{{"a.side", 1}, {"a.side", 1}}, []string{"a.side"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if names := nearestClaims(test.claims); !reflect.DeepEqual(names, test.want) {
+				t.Errorf("got %v, want %v", names, test.want)
+			}
+		})
+	}
+}
+
+// TestTrackCoplanarFaces checks that the coplanar tops of two separate boxes keep their own names. Both tops lie in the
+// same plane and are close enough to match either name by geometry alone.
+func TestTrackCoplanarFaces(t *testing.T) {
+	cad := NewMakerCad()
+	first := cad.MakeBox(cad.TopPlane, 10, 10, 10, false)
+	second := first.Translated(20, 0, 0)
+	up := func(f *Face) bool { return f.IsPlanar() && f.Normal().Z() > 0.5 }
+	names := map[string]ListOfFace{
+		"first.top":  first.Faces().Matching(up),
+		"second.top": second.Faces().Matching(up),
+	}
+
+	operation, err := first.Combine(ListOfShape{second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracked := trackFaces(names, operation.Shape())
+	for name, x := range map[string]float64{"first.top": 5, "second.top": 25} {
+		faces := tracked[name]
+		if len(faces) != 1 {
+			t.Errorf("%s tracked %d faces, want 1", name, len(faces))
+			continue
+		}
+		if center := signatureOf(faces[0]).center; math.Abs(center.X()-x) > namingTolerance {
+			t.Errorf("%s tracked the face centered at x=%v, want x=%v", name, center.X(), x)
+		}
+	}
+}
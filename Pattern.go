@@ -0,0 +1,108 @@
+package makercad
+
+import (
+	"errors"
+	"math"
+
+	"github.com/marcuswu/makercad/sketcher"
+
+	"github.com/marcuswu/gooccwrapper/brep"
+	"github.com/marcuswu/gooccwrapper/topods"
+)
+
+// LinearPattern creates count copies of this Shape spaced along the direction, starting with this Shape itself.
+// The copies are returned as a single compound Shape.
+func (s Shape) LinearPattern(direction *sketcher.Vector, count int, spacing float64) (*CadOperation, error) {
+	return s.LinearPatternMerging(direction, count, spacing, MergeTypeNew, ListOfShape{})
+}
+
+// LinearPatternMerging creates count copies of this Shape spaced along the direction and performs the specified
+// boolean operation with all of the copies at once on the shapes in the provided list
+func (s Shape) LinearPatternMerging(direction *sketcher.Vector, count int, spacing float64, merge MergeType, list ListOfShape) (*CadOperation, error) {
+	if count < 1 {
+		return nil, errors.New("pattern count must be at least 1")
+	}
+	length := math.Sqrt(direction.X*direction.X + direction.Y*direction.Y + direction.Z*direction.Z)
+	if length == 0 {
+		return nil, errors.New("pattern direction must have non-zero length")
+	}
+
+	step := sketcher.Vector{X: direction.X / length * spacing, Y: direction.Y / length * spacing, Z: direction.Z / length * spacing}
+	copies := make(ListOfShape, 0, count)
+	for i := range count {
+		copies = append(copies, s.Translated(step.X*float64(i), step.Y*float64(i), step.Z*float64(i)))
+	}
+	return patternOperation(copies, merge, list), nil
+}
+
+// CircularPattern creates count copies of this Shape rotated about the axis, starting with this Shape itself.
+// The copies are spread evenly over totalAngle radians with one at each end. A total angle of 2π spreads them
+// around the full circle without placing the last copy on top of the first. The copies are returned as a single compound Shape.
+func (s Shape) CircularPattern(axis *sketcher.Axis, count int, totalAngle float64) (*CadOperation, error) {
+	return s.CircularPatternMerging(axis, count, totalAngle, MergeTypeNew, ListOfShape{})
+}
+
+// CircularPatternMerging creates count copies of this Shape rotated about the axis over totalAngle radians (see
+// [Shape.CircularPattern]) and performs the specified boolean operation with all of the copies at once on the shapes
+// in the provided list
+func (s Shape) CircularPatternMerging(axis *sketcher.Axis, count int, totalAngle float64, merge MergeType, list ListOfShape) (*CadOperation, error) {
+	if count < 1 {
+		return nil, errors.New("pattern count must be at least 1")
+	}
+	if axis.Direction.X == 0 && axis.Direction.Y == 0 && axis.Direction.Z == 0 {
+		return nil, errors.New("pattern axis must have a non-zero direction")
+	}
+
+	step := totalAngle / float64(max(count-1, 1))
+	if math.Abs(math.Abs(totalAngle)-2*math.Pi) < 1e-9 {
+		step = totalAngle / float64(count)
+	}
+	copies := make(ListOfShape, 0, count)
+	for i := range count {
+		copies = append(copies, s.Rotated(axis, step*float64(i)))
+	}
+	return patternOperation(copies, merge, list), nil
+}
+
+// EdgePattern creates count copies of this Shape moved to points evenly spaced along a line, arc or circle edge (see
+// [sketcher.Edge.PointsAlong]). This Shape is expected to sit at the first vertex of the edge. The copies keep the
+// orientation of this Shape. The copies are returned as a single compound Shape.
+func (s Shape) EdgePattern(edge *sketcher.Edge, count int) (*CadOperation, error) {
+	return s.EdgePatternMerging(edge, count, MergeTypeNew, ListOfShape{})
+}
+
+// EdgePatternMerging creates count copies of this Shape moved along the edge (see [Shape.EdgePattern]) and performs
+// the specified boolean operation with all of the copies at once on the shapes in the provided list
+func (s Shape) EdgePatternMerging(edge *sketcher.Edge, count int, merge MergeType, list ListOfShape) (*CadOperation, error) {
+	points, err := edge.PointsAlong(count)
+	if err != nil {
+		return nil, err
+	}
+
+	copies := make(ListOfShape, 0, count)
+	for _, p := range points {
+		copies = append(copies, s.Translated(p.X-points[0].X, p.Y-points[0].Y, p.Z-points[0].Z))
+	}
+	return patternOperation(copies, merge, list), nil
+}
+
+// patternOperation returns the copies of a pattern as one compound, or performs the boolean operation with all of them
+// as tools on the shapes in the provided list
+func patternOperation(copies ListOfShape, merge MergeType, list ListOfShape) *CadOperation {
+	if merge == MergeTypeNew || len(list) < 1 {
+		compound := topods.NewCompound()
+		builder := brep.NewBuilder()
+		builder.MakeCompound(compound)
+		for i := range copies {
+			builder.Add(compound, copies[i].Shape)
+		}
+		return &CadOperation{[]Shape{{topods.NewShapeFromRef(topods.TopoDSShape(compound.Compound))}}, nil}
+	}
+
+	operation := mergeTypeToOperation(merge)
+	operation.SetTools(copies.ToCascadeList())
+	operation.SetArguments(list.ToCascadeList())
+	operation.Build()
+
+	return &CadOperation{copies, operation}
+}
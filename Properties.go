@@ -0,0 +1,77 @@
+package makercad
+
+import (
+	"github.com/marcuswu/gooccwrapper/brepgprop"
+	"github.com/marcuswu/gooccwrapper/gp"
+	"github.com/marcuswu/gooccwrapper/gprop"
+)
+
+const (
+	// measureLinearDeflection and measureAngularDeflection are the mesh precision used to measure shapes.
+	// The linear deflection is a fraction of the size of each edge and face.
+	measureLinearDeflection  = 0.001
+	measureAngularDeflection = 0.02
+)
+
+// MassProperties are the volume properties of a solid shape
+type MassProperties struct {
+	Volume float64
+	// CenterOfMass is the center of mass at uniform density
+	CenterOfMass gp.Pnt
+	// Inertia is the inertia tensor about the center of mass at unit density
+	Inertia [3][3]float64
+}
+
+// MassProperties integrates over the solid enclosed by this shape. The wrapper's brepgprop has linear and surface
+// properties but no volume properties, so a copy of the shape is triangulated finely and the mesh is integrated.
+// Results are within the mesh precision of the exact values and change slightly with the deflections used to mesh,
+// so curved shapes do not give exactly the values OpenCascade's own volume properties would.
+// The shape must be closed; the properties of an open shell are meaningless.
+func (s Shape) MassProperties() (MassProperties, error) {
+	solid, err := triangulate(ListOfShape{s}, measureLinearDeflection, measureAngularDeflection)
+	if err != nil {
+		return MassProperties{}, err
+	}
+	properties := solid.MassProperties()
+	return MassProperties{
+		Volume:       properties.Volume,
+		CenterOfMass: gp.NewPnt(properties.Center.X, properties.Center.Y, properties.Center.Z),
+		Inertia:      properties.Inertia,
+	}, nil
+}
+
+// Volume returns the volume enclosed by this shape
+func (s Shape) Volume() (float64, error) {
+	properties, err := s.MassProperties()
+	return properties.Volume, err
+}
+
+// Mass returns the mass of this shape made of a material of the provided density, in mass units per cubic model unit
+func (s Shape) Mass(density float64) (float64, error) {
+	volume, err := s.Volume()
+	return volume * density, err
+}
+
+// CenterOfMass returns the center of mass of this shape at uniform density
+func (s Shape) CenterOfMass() (gp.Pnt, error) {
+	properties, err := s.MassProperties()
+	return properties.CenterOfMass, err
+}
+
+// InertiaTensor returns the inertia tensor of this shape about its center of mass at the provided density
+func (s Shape) InertiaTensor(density float64) ([3][3]float64, error) {
+	properties, err := s.MassProperties()
+	for i := range properties.Inertia {
+		for j := range properties.Inertia[i] {
+			properties.Inertia[i][j] *= density
+		}
+	}
+	return properties.Inertia, err
+}
+
+// SurfaceArea returns the total area of the faces of this shape
+func (s Shape) SurfaceArea() float64 {
+	props := gprop.NewGProps()
+	brepgprop.SurfaceProperties(s.Shape, props, false, false)
+	return props.Mass()
+}
@@ -0,0 +1,95 @@
+package makercad
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	metricCountersinkAngle   = math.Pi / 2
+	imperialCountersinkAngle = 82 * math.Pi / 180
+	millimetersPerInch       = 25.4
+)
+
+// ScrewSize holds the hole sizes for a standard screw in millimeters. Counterbores fit a socket head cap screw and
+// countersinks fit a flat head screw.
+type ScrewSize struct {
+	Name                string
+	Diameter            float64
+	Close               float64
+	Normal              float64
+	Loose               float64
+	TapDrill            float64
+	CounterboreDiameter float64
+	CounterboreDepth    float64
+	CountersinkDiameter float64
+	// CountersinkAngle is the included angle of the countersink in radians
+	CountersinkAngle float64
+}
+
+// Clearance returns the hole diameter for the fit
+func (s ScrewSize) Clearance(fit HoleFit) float64 {
+	switch fit {
+	case HoleFitClose:
+		return s.Close
+	case HoleFitLoose:
+		return s.Loose
+	case HoleFitTap:
+		return s.TapDrill
+	default:
+		return s.Normal
+	}
+}
+
+func metricScrew(name string, diameter, close, normal, loose, tap, counterbore, countersink float64) ScrewSize {
+	return ScrewSize{name, diameter, close, normal, loose, tap, counterbore, diameter, countersink, metricCountersinkAngle}
+}
+
+// imperialScrew converts sizes in inches to a ScrewSize
+func imperialScrew(name string, diameter, close, normal, loose, tap, counterbore, countersink float64) ScrewSize {
+	size := metricScrew(name, diameter, close, normal, loose, tap, counterbore, countersink)
+	for _, value := range []*float64{&size.Diameter, &size.Close, &size.Normal, &size.Loose, &size.TapDrill,
+		&size.CounterboreDiameter, &size.CounterboreDepth, &size.CountersinkDiameter} {
+		*value *= millimetersPerInch
+	}
+	size.CountersinkAngle = imperialCountersinkAngle
+	return size
+}
+
+// screwSizes are ISO 273 metric clearance holes and common unified thread clearance and tap drill sizes
+var screwSizes = []ScrewSize{
+	metricScrew("M1.6", 1.6, 1.7, 1.8, 2.0, 1.25, 3.5, 3.0),
+	metricScrew("M2", 2, 2.2, 2.4, 2.6, 1.6, 4.4, 3.8),
+	metricScrew("M2.5", 2.5, 2.7, 2.9, 3.1, 2.05, 5.4, 4.7),
+	metricScrew("M3", 3, 3.2, 3.4, 3.6, 2.5, 6.5, 6.72),
+	metricScrew("M4", 4, 4.3, 4.5, 4.8, 3.3, 8, 8.96),
+	metricScrew("M5", 5, 5.3, 5.5, 5.8, 4.2, 10, 11.2),
+	metricScrew("M6", 6, 6.4, 6.6, 7, 5, 11, 13.44),
+	metricScrew("M8", 8, 8.4, 9, 10, 6.8, 15, 17.92),
+	metricScrew("M10", 10, 10.5, 11, 12, 8.5, 18, 22.4),
+	metricScrew("M12", 12, 13, 13.5, 14.5, 10.2, 20, 26.88),
+	metricScrew("M16", 16, 17, 17.5, 18.5, 14, 26, 33.6),
+	metricScrew("M20", 20, 21, 22, 24, 17.5, 33, 40.32),
+	imperialScrew("#2-56", 0.086, 0.089, 0.096, 0.104, 0.07, 0.188, 0.172),
+	imperialScrew("#4-40", 0.112, 0.116, 0.1285, 0.144, 0.089, 0.219, 0.225),
+	imperialScrew("#6-32", 0.138, 0.144, 0.1495, 0.1695, 0.1065, 0.281, 0.279),
+	imperialScrew("#8-32", 0.164, 0.1695, 0.177, 0.196, 0.136, 0.312, 0.332),
+	imperialScrew("#10-24", 0.19, 0.196, 0.201, 0.221, 0.1495, 0.375, 0.385),
+	imperialScrew("#10-32", 0.19, 0.196, 0.201, 0.221, 0.159, 0.375, 0.385),
+	imperialScrew("1/4-20", 0.25, 0.257, 0.266, 0.281, 0.201, 0.438, 0.507),
+	imperialScrew("1/4-28", 0.25, 0.257, 0.266, 0.281, 0.213, 0.438, 0.507),
+	imperialScrew("5/16-18", 0.3125, 0.323, 0.332, 0.358, 0.257, 0.531, 0.635),
+	imperialScrew("3/8-16", 0.375, 0.386, 0.397, 0.4219, 0.3125, 0.625, 0.762),
+	imperialScrew("1/2-13", 0.5, 0.5156, 0.5312, 0.5625, 0.4219, 0.812, 1.0),
+}
+
+// LookupScrew returns the sizes of a standard screw such as "M3", "#4-40" or "1/4-20"
+func LookupScrew(name string) (ScrewSize, error) {
+	for _, size := range screwSizes {
+		if strings.EqualFold(size.Name, strings.TrimSpace(name)) {
+			return size, nil
+		}
+	}
+	return ScrewSize{}, fmt.Errorf("unknown screw size %q", name)
+}
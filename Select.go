@@ -0,0 +1,100 @@
+package makercad
+
+import (
+	"fmt"
+	"slices"
+
+	floatUtils "github.com/marcuswu/dlineate/utils"
+	"github.com/marcuswu/makercad/selector"
+	"github.com/marcuswu/makercad/sketcher"
+
+	"github.com/marcuswu/gooccwrapper/gp"
+)
+
+// SelectEdges returns the edges matching a selector string such as ">Z", "|X and <Y" or "%CIRCLE" (see the selector
+// package). Edges are placed by their center of mass.
+func SelectEdges(edges sketcher.ListOfEdge, expression string) (sketcher.ListOfEdge, error) {
+	node, err := selector.Parse(expression)
+	if err != nil {
+		return nil, err
+	}
+	return selector.Evaluate(node, edges, selectEdgeNode)
+}
+
+// selectEdgeNode evaluates a selector node which is not a boolean operator
+func selectEdgeNode(node selector.Node, edges sketcher.ListOfEdge) (sketcher.ListOfEdge, error) {
+	switch n := node.(type) {
+	case selector.Extreme:
+		direction := gp.NewVec(n.Direction.X, n.Direction.Y, n.Direction.Z)
+		along := func(e *sketcher.Edge) float64 {
+			center := e.Center()
+			return gp.NewVec(center.X(), center.Y(), center.Z()).Dot(direction) / direction.Magnitude()
+		}
+		return extremes(edges, along, n), nil
+	case selector.Parallel:
+		return edges.Parallel(gp.NewDir(n.Direction.X, n.Direction.Y, n.Direction.Z)), nil
+	case selector.Perpendicular:
+		direction := gp.NewDir(n.Direction.X, n.Direction.Y, n.Direction.Z)
+		return edges.Matching(func(e *sketcher.Edge) bool {
+			return e.IsLine() && floatUtils.StandardFloatCompare(lineDirection(e).Dot(direction), 0) == 0
+		}), nil
+	case selector.Facing:
+		direction := gp.NewDir(n.Direction.X, n.Direction.Y, n.Direction.Z)
+		if n.Opposite {
+			direction = gp.NewDir(-n.Direction.X, -n.Direction.Y, -n.Direction.Z)
+		}
+		return edges.Matching(func(e *sketcher.Edge) bool { return e.IsLine() && lineDirection(e).IsEqual(direction) }), nil
+	case selector.Type:
+		switch n.Name {
+		case "LINE":
+			return edges.IsLine(), nil
+		case "CIRCLE":
+			return edges.IsCircle(), nil
+		case "ARC":
+			return edges.Matching(func(e *sketcher.Edge) bool { return e.IsArc() }), nil
+		case "ELLIPSE":
+			return edges.Matching(func(e *sketcher.Edge) bool { return e.IsEllipse() }), nil
+		}
+		return nil, fmt.Errorf("unknown edge type %s, expected %%LINE, %%CIRCLE, %%ARC or %%ELLIPSE", n.Name)
+	}
+	return nil, fmt.Errorf("selector %s does not apply to edges", node)
+}
+
+// lineDirection returns the direction from the first to the last vertex of the edge
+func lineDirection(e *sketcher.Edge) gp.Dir {
+	first, last := e.FirstVertex(), e.LastVertex()
+	return gp.NewDir(last.X()-first.X(), last.Y()-first.Y(), last.Z()-first.Z())
+}
+
+// SelectVertices returns the vertices matching a selector string such as ">Z" or "<X and >Y". Only the >, < and
+// boolean selectors apply to vertices.
+func SelectVertices(vertices sketcher.ListOfVertex, expression string) (sketcher.ListOfVertex, error) {
+	node, err := selector.Parse(expression)
+	if err != nil {
+		return nil, err
+	}
+	return selector.Evaluate(node, vertices, func(node selector.Node, vertices sketcher.ListOfVertex) (sketcher.ListOfVertex, error) {
+		n, ok := node.(selector.Extreme)
+		if !ok {
+			return nil, fmt.Errorf("selector %s does not apply to vertices", node)
+		}
+		direction := gp.NewVec(n.Direction.X, n.Direction.Y, n.Direction.Z)
+		along := func(v *sketcher.Vertex) float64 {
+			point := v.ToPoint()
+			return gp.NewVec(point.X(), point.Y(), point.Z()).Dot(direction) / direction.Magnitude()
+		}
+		return extremes(vertices, along, n), nil
+	})
+}
+
+// extremes returns the group of items at the extreme of the selector's direction chosen by its index
+func extremes[S ~[]E, E any](items S, along func(E) float64, n selector.Extreme) S {
+	sorted := slices.Clone(items)
+	slices.SortFunc(sorted, func(a, b E) int {
+		if n.Maximum {
+			return floatUtils.StandardFloatCompare(along(b), along(a))
+		}
+		return floatUtils.StandardFloatCompare(along(a), along(b))
+	})
+	return selector.Group(sorted, func(a, b E) bool { return floatUtils.StandardFloatCompare(along(a), along(b)) == 0 }, n.Index)
+}
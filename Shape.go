@@ -1,6 +1,10 @@
 package makercad
 
 import (
+	"slices"
+
+	"github.com/marcuswu/makercad/sketcher"
+
 	"github.com/marcuswu/gooccwrapper/brepalgoapi"
 	"github.com/marcuswu/gooccwrapper/brepbuilderapi"
 	"github.com/marcuswu/gooccwrapper/gp"
@@ -24,6 +28,59 @@ func (s Shape) Faces() ListOfFace {
 	return faces
 }
 
+// Edges returns the edges of this shape. An edge shared by several faces is listed once.
+func (s Shape) Edges() sketcher.ListOfEdge {
+	return s.topology().edges.unique
+}
+
+// Vertices returns the vertices of this shape. A vertex shared by several edges is listed once.
+func (s Shape) Vertices() sketcher.ListOfVertex {
+	return s.topology().vertices.unique
+}
+
+// FacesOfEdge returns the faces of this shape bounded by the edge, usually the two faces meeting at it
+func (s Shape) FacesOfEdge(edge *sketcher.Edge) ListOfFace {
+	t := s.topology()
+	id := t.edges.find(edge)
+	if id < 0 {
+		return ListOfFace{}
+	}
+	return t.facesOf(t.edgeFaces[id])
+}
+
+// EdgesOfVertex returns the edges of this shape which start or end at the vertex
+func (s Shape) EdgesOfVertex(vertex *sketcher.Vertex) sketcher.ListOfEdge {
+	t := s.topology()
+	id := t.vertices.find(vertex)
+	if id < 0 {
+		return sketcher.ListOfEdge{}
+	}
+	edges := make(sketcher.ListOfEdge, 0, len(t.vertexEdges[id]))
+	for _, e := range t.vertexEdges[id] {
+		edges = append(edges, t.edges.unique[e])
+	}
+	return edges
+}
+
+// AdjacentFaces returns the faces of this shape which share an edge with the face
+func (s Shape) AdjacentFaces(face *Face) ListOfFace {
+	t := s.topology()
+	adjacent := make([]int, 0)
+	for _, edge := range face.Edges() {
+		id := t.edges.find(edge)
+		if id < 0 {
+			continue
+		}
+		for _, f := range t.edgeFaces[id] {
+			if !slices.Contains(adjacent, f) && !t.faces[f].isSame(face) {
+				adjacent = append(adjacent, f)
+			}
+		}
+	}
+	slices.Sort(adjacent)
+	return t.facesOf(adjacent)
+}
+
 // ListOfShape is simply a list of filterable and sortable shapes.
 type ListOfShape []Shape
 
@@ -36,6 +93,19 @@ func (l ListOfShape) ToCascadeList() toptools.ListOfShape {
 	return list
 }
 
+// Combine performs a boolean union of this Shape and the tools
+func (s Shape) Combine(tools ListOfShape) (*CadOperation, error) {
+	operation := brepalgoapi.NewFuse().ToBooleanOperation()
+	arguments := make(ListOfShape, 1)
+	arguments[0] = s
+
+	operation.SetTools(tools.ToCascadeList())
+	operation.SetArguments(arguments.ToCascadeList())
+	operation.Build()
+
+	return NewCadOperation(tools, &operation), nil
+}
+
 // Remove performs a boolean subtraction of the tools from this Shape
 func (s Shape) Remove(tools ListOfShape) (*CadOperation, error) {
 	operation := brepalgoapi.NewCut().ToBooleanOperation()
@@ -54,3 +124,48 @@ func (s Shape) Transform(trsf gp.Trsf) Shape {
 	transform := brepbuilderapi.NewTransform(s.Shape, trsf)
 	return Shape{transform.Shape()}
 }
+
+// Translated returns a copy of this Shape moved by x, y and z
+func (s Shape) Translated(x float64, y float64, z float64) Shape {
+	trsf := gp.NewTrsf()
+	trsf.SetTranslation(gp.NewVec(x, y, z))
+	return s.Transform(trsf)
+}
+
+// Rotated returns a copy of this Shape rotated about the axis by the angle in radians.
+// Positive angles follow the right hand rule about the axis direction. Like every angle in MakerCad, the angle is in
+// radians; [utils.ToRadians] converts from degrees.
+func (s Shape) Rotated(axis *sketcher.Axis, angle float64) Shape {
+	trsf := gp.NewTrsf()
+	trsf.SetRotation(axis.Ax1(), angle)
+	return s.Transform(trsf)
+}
+
+// Mirrored returns a copy of this Shape reflected across the plane
+func (s Shape) Mirrored(plane *sketcher.PlaneParameters) Shape {
+	trsf := gp.NewTrsf()
+	trsf.SetMirrorAx2(plane.Ax2())
+	return s.Transform(trsf)
+}
+
+// Mirror reflects this Shape across the plane. When merge is true the reflection is fused with this Shape to create a
+// symmetric body, otherwise the operation holds only the reflected copy.
+func (s Shape) Mirror(plane *sketcher.PlaneParameters, merge bool) (*CadOperation, error) {
+	mirrored := s.Mirrored(plane)
+	if !merge {
+		return NewCadOperation(ListOfShape{mirrored}, nil), nil
+	}
+	return s.Combine(ListOfShape{mirrored})
+}
+
+// Placed returns a copy of this Shape moved so that it sits on the plane to as it sat on the plane from.
+// For example, a part modelled on the TopPlane can be placed on a face's plane.
+func (s Shape) Placed(from *sketcher.PlaneParameters, to *sketcher.PlaneParameters) Shape {
+	global := sketcher.NewPlaneParameters().Plane()
+	// Move the from plane to the global origin, then the global origin to the to plane
+	fromToGlobal := gp.NewTrsf()
+	fromToGlobal.SetTransformation(global, from.Plane())
+	globalToTo := gp.NewTrsf()
+	globalToTo.SetTransformation(to.Plane(), global)
+	return s.Transform(fromToGlobal).Transform(globalToTo)
+}
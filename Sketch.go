@@ -1,10 +1,35 @@
 package makercad
 
-import "github.com/marcuswu/makercad/sketcher"
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/marcuswu/makercad/sketcher"
+	"github.com/marcuswu/makercad/utils"
+
+	"github.com/marcuswu/gooccwrapper/brepprimapi"
+	"github.com/marcuswu/gooccwrapper/breptools"
+	"github.com/marcuswu/gooccwrapper/geomlprop"
+	"github.com/marcuswu/gooccwrapper/gp"
+	"github.com/marcuswu/gooccwrapper/topexp"
+	"github.com/marcuswu/gooccwrapper/topods"
+)
+
+// projectionDeflection is the precision of lines approximating a projected tilted circle as a fraction of its radius
+const projectionDeflection = 0.001
 
 // Sketch represents a 2D sketch on a face or plane. Sketches can be solved for a set of constraints. Sketches are created via an instance of [MakerCad]
 type Sketch struct {
-	solver sketcher.SketchSolver
+	solver     sketcher.SketchSolver
+	references map[sketcher.Entity]*sketcher.Edge
+}
+
+func newSketch(planer sketcher.Planer) *Sketch {
+	return &Sketch{
+		solver:     sketcher.NewDlineateSolver(planer),
+		references: make(map[sketcher.Entity]*sketcher.Edge),
+	}
 }
 
 // Solve will attempt to solve the sketch based on the established constraints
@@ -47,6 +72,13 @@ func (s *Sketch) Point(x float64, y float64) *sketcher.Point {
 	return s.solver.CreatePoint(x, y)
 }
 
+// Mirror creates mirrored copies of the provided entities across axis. Each copy is constrained symmetric to its original
+// so only half of a symmetric profile needs to be drawn and dimensioned. Points shared by connected entities are
+// mirrored once. The axis itself is not mirrored.
+func (s *Sketch) Mirror(entities []sketcher.Entity, axis *sketcher.Line) []sketcher.Entity {
+	return sketcher.MirrorEntities(entities, axis)
+}
+
 // OverConstrained returns a string representation of conflicting constraints
 func (s *Sketch) OverConstrained() []string {
 	return s.solver.OverConstrained()
@@ -62,13 +94,228 @@ func (s *Sketch) ExportImage(file string, args ...float64) error {
 	return s.solver.ExportImage(file, args...)
 }
 
-// Project projects an edge to the current sketch. Note that curves are not projected, just points. A circle projected between non-normal surfaces will not become an ellipse.
+// Project projects an edge to the current sketch. Returns nil if the edge cannot be projected as a single entity.
+//
+// Deprecated: Project cannot report why an edge was not projected. Use [Sketch.ProjectEdge], which returns every
+// entity of the projection and an error.
 func (s *Sketch) Project(edge *sketcher.Edge) sketcher.Entity {
-	if edge.IsCircle() {
-		return edge.GetCircle(s.solver)
+	entities, err := s.ProjectEdge(edge)
+	if err != nil || len(entities) != 1 {
+		return nil
+	}
+	return entities[0]
+}
+
+// ProjectEdge projects an edge onto the sketch plane as fixed reference geometry tied to the source edge. The entities
+// are construction geometry so they guide the sketch without becoming part of its profiles.
+// Lines project to lines, and circles and arcs whose axis is along the sketch normal project to circles and arcs.
+// The sketcher has no ellipse entity, so a tilted circle or arc, which projects to an ellipse, becomes a chain of lines
+// through points on the circle within projectionDeflection of its radius.
+// Ellipses, B-splines and other curves are not supported and return an error: the wrapper cannot evaluate points on
+// them or project them onto a plane.
+func (s *Sketch) ProjectEdge(edge *sketcher.Edge) ([]sketcher.Entity, error) {
+	var entities []sketcher.Entity
+	switch {
+	case edge.IsLine():
+		entities = []sketcher.Entity{edge.GetLine(s.solver)}
+	case edge.IsArc() && edge.IsParallelToSketch(s.solver):
+		entities = []sketcher.Entity{edge.GetArc(s.solver)}
+	case edge.IsCircle() && edge.IsParallelToSketch(s.solver):
+		entities = []sketcher.Entity{edge.GetCircle(s.solver)}
+	case edge.IsCircle():
+		points, err := circlePoints(edge)
+		if err != nil {
+			return nil, err
+		}
+		for _, line := range edge.GetPolyline(s.solver, points) {
+			entities = append(entities, line)
+		}
+	default:
+		if edge.IsEllipse() {
+			return nil, errors.New("cannot project ellipse edges: only lines, circles and arcs are supported")
+		}
+		return nil, errors.New("cannot project spline or other free-form edges: only lines, circles and arcs are supported")
+	}
+
+	for _, entity := range entities {
+		entity.SetConstruction(true)
+		s.references[entity] = edge
+	}
+	return entities, nil
+}
+
+// circlePoints returns points along a circle or arc close enough together for the lines through them to stay within
+// projectionDeflection of its radius
+func circlePoints(edge *sketcher.Edge) ([]sketcher.Vector, error) {
+	step := 2 * math.Acos(1-projectionDeflection)
+	segments := max(int(math.Ceil(edge.LineLength()/edge.CircleRadius()/step)), 4)
+	if edge.IsArc() {
+		return edge.PointsAlong(segments + 1)
+	}
+	points, err := edge.PointsAlong(segments)
+	if err != nil {
+		return nil, err
+	}
+	return append(points, points[0]), nil
+}
+
+// ProjectFace projects the outline of a face onto the sketch plane as reference geometry.
+// Edges which cannot be projected are skipped and reported in the returned error.
+func (s *Sketch) ProjectFace(face *Face) ([]sketcher.Entity, error) {
+	edges := face.Edges()
+	entities := make([]sketcher.Entity, 0, len(edges))
+	errs := make([]error, 0)
+	for i, edge := range edges {
+		projected, err := s.ProjectEdge(edge)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("edge %d: %w", i, err))
+			continue
+		}
+		entities = append(entities, projected...)
+	}
+	return entities, errors.Join(errs...)
+}
+
+// IsReference returns whether the entity was projected into the sketch from existing geometry
+func (s *Sketch) IsReference(entity sketcher.Entity) bool {
+	_, ok := s.references[entity]
+	return ok
+}
+
+// ReferenceSource returns the edge a reference entity was projected from, or nil if the entity is not a reference or was restored by [UnmarshalSketch]
+func (s *Sketch) ReferenceSource(entity sketcher.Entity) *sketcher.Edge {
+	return s.references[entity]
+}
+
+// IntersectWith returns the curves where the sketch plane cuts through the shape as reference entities
+func (s *Sketch) IntersectWith(shape Shape) ([]sketcher.Entity, error) {
+	faces, err := s.sectionFaces(shape)
+	if err != nil {
+		return nil, err
 	}
-	if edge.IsLine() {
-		return edge.GetLine(s.solver)
+
+	entities := make([]sketcher.Entity, 0)
+	errs := make([]error, 0)
+	for _, face := range faces {
+		projected, err := s.ProjectFace(face)
+		entities = append(entities, projected...)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return entities, errors.Join(errs...)
+}
+
+// Silhouette returns the outline of the shape as seen along the sketch normal as reference entities.
+// The outline is found by sweeping each face of the shape through the sketch plane and sectioning the combined result.
+// Curved faces that fold over as seen along the sketch normal, such as a cylinder lying on its side, would sweep into
+// a self intersecting solid and return an error instead.
+func (s *Sketch) Silhouette(shape Shape) ([]sketcher.Entity, error) {
+	coords := s.solver.CoordinateSystem()
+	normal := gp.NewVecDir(coords.Direction())
+	size := 2*shapeRadius(shape, coords.Location()) + 1
+
+	shift := gp.NewTrsf()
+	shift.SetTranslation(normal.Multiplied(-size))
+	shadows := make(ListOfShape, 0)
+	for _, face := range shape.Faces() {
+		// Faces seen edge on add nothing to the outline that their neighbours do not
+		if s.isEdgeOn(face) {
+			continue
+		}
+		if !face.IsPlanar() && foldsAlong(face, coords.Direction()) {
+			return nil, errors.New("cannot find the silhouette of a curved face that folds over along the sketch normal")
+		}
+		shifted := face.AsShape().Transform(shift)
+		shiftedFace := topods.NewFaceFromRef(topods.TopoDSFace(shifted.Shape.Shape))
+		shadows = append(shadows, Shape{brepprimapi.NewMakePrism(shiftedFace, normal.Multiplied(2*size)).Shape()})
+	}
+	if len(shadows) < 1 {
+		return nil, errors.New("shape has no faces visible along the sketch normal")
+	}
+
+	shadow := shadows[0]
+	if len(shadows) > 1 {
+		op, err := shadow.Combine(shadows[1:])
+		if err != nil {
+			return nil, err
+		}
+		shadow = op.Shape()
+	}
+	if len(shadow.Faces()) < 1 {
+		return nil, errors.New("could not combine the faces of the shape into a silhouette")
+	}
+	return s.IntersectWith(shadow)
+}
+
+// foldSamples is the number of steps in each surface direction when looking for a face folding over
+const foldSamples = 8
+
+// foldsAlong returns whether the surface of the face has normals facing both toward and away from the direction
+func foldsAlong(face *Face, direction gp.Dir) bool {
+	umin, umax, vmin, vmax := breptools.UVBounds(face.face)
+	surface := face.face.Surface()
+	toward, away := false, false
+	for i := 0; i <= foldSamples; i++ {
+		u := umin + (umax-umin)*float64(i)/foldSamples
+		for j := 0; j <= foldSamples; j++ {
+			v := vmin + (vmax-vmin)*float64(j)/foldSamples
+			props := geomlprop.NewSLProps(surface, u, v, 1, 0.01)
+			dot := props.Normal().Dot(direction)
+			props.Free()
+			toward = toward || dot > utils.Confusion
+			away = away || dot < -utils.Confusion
+		}
+	}
+	return toward && away
+}
+
+// sectionFaces cuts away the part of the shape in front of the sketch plane and returns the faces left lying on it
+func (s *Sketch) sectionFaces(shape Shape) (ListOfFace, error) {
+	coords := s.solver.CoordinateSystem()
+	plane := sketcher.NewPlaneParametersFromCoordinateSystem(coords)
+	size := 4*shapeRadius(shape, coords.Location()) + 1
+
+	origin := coords.Location().Translated(
+		gp.NewVecDir(coords.XDirection()).Multiplied(-size / 2),
+	).Translated(
+		gp.NewVecDir(coords.YDirection()).Multiplied(-size / 2),
+	)
+	position := gp.NewAx2(origin, coords.Direction(), coords.XDirection())
+	halfSpace := Shape{brepprimapi.NewMakeBox(position, size, size, size).Shape()}
+
+	op, err := shape.Remove(ListOfShape{halfSpace})
+	if err != nil {
+		return nil, err
+	}
+	faces := op.Shape().Faces().Matching(func(f *Face) bool { return f.IsOnPlane(plane) })
+	if len(faces) < 1 {
+		return nil, errors.New("sketch plane does not intersect the shape")
+	}
+	return faces, nil
+}
+
+// isEdgeOn returns whether the face is seen edge on along the sketch normal: a planar face perpendicular to the sketch
+// or a cylindrical face whose axis is along the sketch normal
+func (s *Sketch) isEdgeOn(face *Face) bool {
+	normal := s.solver.CoordinateSystem().Direction()
+	if face.IsPlanar() {
+		return math.Abs(face.Normal().Dot(normal)) < utils.Confusion
+	}
+	if face.IsCylindrical() {
+		return face.Edges().IsCircle().FirstMatching(func(e *sketcher.Edge) bool {
+			return e.IsParallelToSketch(s.solver)
+		}) != nil
+	}
+	return false
+}
+
+// shapeRadius returns the distance from center to the farthest vertex of the shape
+func shapeRadius(shape Shape, center gp.Pnt) float64 {
+	radius := 0.0
+	for ex := topexp.NewExplorer(shape.Shape, topexp.Vertex); ex.More(); ex.Next() {
+		vertex := topods.NewVertexFromRef(topods.TopoDSVertex(ex.Current().Shape))
+		radius = math.Max(radius, center.Distance(vertex.Pnt()))
 	}
-	return nil
+	return radius
 }
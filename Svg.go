@@ -0,0 +1,427 @@
+package makercad
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/marcuswu/makercad/glyph"
+	"github.com/marcuswu/makercad/sketcher"
+	"github.com/marcuswu/makercad/svg"
+	"github.com/marcuswu/makercad/utils"
+)
+
+// svgCurveTolerance is the maximum distance between an SVG curve and the lines approximating it in sketch units
+const svgCurveTolerance = 0.01
+
+// ImportSVG reads the outlines of an SVG document into the sketch with their transforms applied.
+// SVG user units are multiplied by scale and the Y axis is flipped so the drawing appears upright on the sketch.
+// Straight segments become lines, circular arcs become arcs and Bézier curves and elliptical arcs are approximated with lines.
+// Each closed subpath is returned as a loop with coincident endpoints which can be turned into a face with [NewFacesFromLoops].
+// Open subpaths, such as stroked lines, do not outline an area. They are skipped and reported in the returned error.
+func (s *Sketch) ImportSVG(reader io.Reader, scale float64) ([]Loop, error) {
+	if scale <= 0 {
+		return nil, errors.New("svg scale must be positive")
+	}
+	paths, err := svg.Read(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	toSketch := func(p svg.Point) [2]float64 {
+		return [2]float64{p.X * scale, -p.Y * scale}
+	}
+	loops := make([]Loop, 0, len(paths))
+	errs := make([]error, 0)
+	for i, path := range paths {
+		if !path.IsClosed() {
+			errs = append(errs, fmt.Errorf("subpath %d is not closed", i))
+			continue
+		}
+		loop := make(Loop, 0, len(path.Segments))
+		for _, segment := range path.Segments {
+			start, end := toSketch(segment.Start()), toSketch(segment.End())
+			switch seg := segment.(type) {
+			case *svg.Line:
+				if start == end {
+					continue
+				}
+				loop = append(loop, s.solver.CreateLine(start[0], start[1], end[0], end[1]))
+			case *svg.Arc:
+				// Sketch arcs run counterclockwise, so arcs sweeping clockwise as displayed are created from their end
+				center := toSketch(seg.Center)
+				if seg.Sweep {
+					loop = append(loop, s.solver.CreateArc(center[0], center[1], end[0], end[1], start[0], start[1]))
+				} else {
+					loop = append(loop, s.solver.CreateArc(center[0], center[1], start[0], start[1], end[0], end[1]))
+				}
+			case *svg.Cubic:
+				polyline := glyph.AppendCubic([][2]float64{start}, toSketch(seg.Control1), toSketch(seg.Control2), end, svgCurveTolerance)
+				for i := 1; i < len(polyline); i++ {
+					if polyline[i-1] == polyline[i] {
+						continue
+					}
+					loop = append(loop, s.solver.CreateLine(polyline[i-1][0], polyline[i-1][1], polyline[i][0], polyline[i][1]))
+				}
+			}
+		}
+		if len(loop) == 0 {
+			continue
+		}
+		s.constrainCoincidentEndpoints(loop)
+		loops = append(loops, loop)
+	}
+	return loops, errors.Join(errs...)
+}
+
+// SVGOptions controls how [Sketch.ExportSVG] draws a sketch. Styles left empty use the defaults.
+type SVGOptions struct {
+	// Geometry styles normal sketch geometry
+	Geometry svg.Style
+	// Construction styles construction geometry
+	Construction svg.Style
+	// Dimension styles dimension lines. Labels are filled with the dimension stroke color.
+	Dimension svg.Style
+	// HideConstruction leaves out construction geometry
+	HideConstruction bool
+	// HideDimensions leaves out dimension annotations
+	HideDimensions bool
+	// TextHeight is the height of dimension labels in sketch units. Defaults to 3% of the sketch size.
+	TextHeight float64
+}
+
+// ExportSVG writes the solved sketch to w as an SVG drawing. Lengths, distances, diameters and angles set by
+// constraints are drawn as dimension annotations labelled with their constraint values.
+// The output depends only on the sketch, so it can be kept under version control and compared between revisions.
+func (s *Sketch) ExportSVG(w io.Writer, opts SVGOptions) error {
+	entities := make([]sketcher.Entity, 0, len(s.solver.Entities()))
+	for _, entity := range s.solver.Entities() {
+		if entity.IsConstruction() && opts.HideConstruction {
+			continue
+		}
+		entities = append(entities, entity)
+	}
+	minX, minY, maxX, maxY := entityBounds(entities)
+
+	textHeight := opts.TextHeight
+	if textHeight <= 0 {
+		textHeight = 0.03 * max(maxX-minX, maxY-minY, 1)
+	}
+	margin := 5 * textHeight
+	styles := []svg.Style{
+		styleOrDefault(opts.Geometry, svg.Style{Stroke: "black", StrokeWidth: textHeight / 10}),
+		styleOrDefault(opts.Construction, svg.Style{Stroke: "gray", StrokeWidth: textHeight / 20, DashArray: svg.FormatNumber(textHeight/2) + " " + svg.FormatNumber(textHeight/4)}),
+		styleOrDefault(opts.Dimension, svg.Style{Stroke: "blue", StrokeWidth: textHeight / 20}),
+	}
+	styles[0].Class, styles[1].Class, styles[2].Class = "geometry", "construction", "dimension"
+	styles = append(styles, svg.Style{Class: "label", Fill: styles[2].Stroke, FontSize: textHeight})
+
+	drawing := &svg.Drawing{
+		ViewBox:  [4]float64{minX - margin, -maxY - margin, maxX - minX + 2*margin, maxY - minY + 2*margin},
+		Styles:   styles,
+		Elements: make([]svg.Element, 0, len(entities)),
+	}
+	for _, entity := range entities {
+		class := "geometry"
+		if entity.IsConstruction() {
+			class = "construction"
+		}
+		switch e := entity.(type) {
+		case *sketcher.Point:
+			drawing.Elements = append(drawing.Elements, &svg.Circle{Class: class, Center: svgPoint(e.X, e.Y), Radius: textHeight / 8})
+		case *sketcher.Line:
+			path := svg.Path{Segments: []svg.Segment{&svg.Line{From: svgPoint(e.Start.X, e.Start.Y), To: svgPoint(e.End.X, e.End.Y)}}}
+			drawing.Elements = append(drawing.Elements, &svg.Outline{Class: class, Path: path})
+		case *sketcher.Arc:
+			// Flipping the Y axis turns counterclockwise sketch arcs into arcs of decreasing SVG angle
+			path := svg.Path{Segments: []svg.Segment{&svg.Arc{
+				From:   svgPoint(e.Start.X, e.Start.Y),
+				To:     svgPoint(e.End.X, e.End.Y),
+				Center: svgPoint(e.Center.X, e.Center.Y),
+				Radius: math.Hypot(e.Start.X-e.Center.X, e.Start.Y-e.Center.Y),
+				Sweep:  false,
+			}}}
+			drawing.Elements = append(drawing.Elements, &svg.Outline{Class: class, Path: path})
+		case *sketcher.Circle:
+			drawing.Elements = append(drawing.Elements, &svg.Circle{Class: class, Center: svgPoint(e.Center.X, e.Center.Y), Radius: e.Radius})
+		}
+	}
+
+	if !opts.HideDimensions {
+		d := &svgDimensioner{sketch: s, textHeight: textHeight}
+		for _, constraint := range s.solver.Constraints() {
+			d.add(constraint)
+		}
+		drawing.Elements = append(drawing.Elements, d.elements...)
+	}
+
+	return svg.Write(w, drawing)
+}
+
+func styleOrDefault(style svg.Style, fallback svg.Style) svg.Style {
+	if style == (svg.Style{}) {
+		return fallback
+	}
+	return style
+}
+
+// svgPoint converts sketch coordinates to SVG coordinates, where Y increases downwards
+func svgPoint(x float64, y float64) svg.Point {
+	return svg.Point{X: x, Y: -y}
+}
+
+// entityBounds returns the extent of the entities in sketch coordinates
+func entityBounds(entities []sketcher.Entity) (float64, float64, float64, float64) {
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	include := func(x, y, r float64) {
+		minX, minY = min(minX, x-r), min(minY, y-r)
+		maxX, maxY = max(maxX, x+r), max(maxY, y+r)
+	}
+	for _, entity := range entities {
+		switch e := entity.(type) {
+		case *sketcher.Point:
+			include(e.X, e.Y, 0)
+		case *sketcher.Line:
+			include(e.Start.X, e.Start.Y, 0)
+			include(e.End.X, e.End.Y, 0)
+		case *sketcher.Arc:
+			include(e.Center.X, e.Center.Y, math.Hypot(e.Start.X-e.Center.X, e.Start.Y-e.Center.Y))
+		case *sketcher.Circle:
+			include(e.Center.X, e.Center.Y, e.Radius)
+		}
+	}
+	if math.IsInf(minX, 1) {
+		return 0, 0, 0, 0
+	}
+	return minX, minY, maxX, maxY
+}
+
+// svgDimensioner draws dimension annotations for constraints in sketch coordinates
+type svgDimensioner struct {
+	sketch     *Sketch
+	textHeight float64
+	elements   []svg.Element
+}
+
+func (d *svgDimensioner) add(constraint *sketcher.Constraint) {
+	entities := constraint.Entities
+	value := constraint.Value
+	switch constraint.Type {
+	case sketcher.ConstraintLineLength:
+		if l := sketcher.AsLine(entities[0]); l != nil && l.Start != nil {
+			d.aligned([2]float64{l.Start.X, l.Start.Y}, [2]float64{l.End.X, l.End.Y}, svg.FormatNumber(value))
+		}
+	case sketcher.ConstraintDistance, sketcher.ConstraintPointProjectedDistance:
+		if value == 0 {
+			return
+		}
+		if a, b, ok := d.closestPoints(entities[0], entities[1]); ok {
+			d.aligned(a, b, svg.FormatNumber(value))
+		}
+	case sketcher.ConstraintPointVerticalDistance, sketcher.ConstraintPointHorizontalDistance:
+		p := sketcher.AsPoint(entities[0])
+		if p == nil {
+			return
+		}
+		vertical := constraint.Type == sketcher.ConstraintPointVerticalDistance
+		if target, ok := d.axisTarget(p, entities[1], vertical); ok {
+			d.aligned([2]float64{p.X, p.Y}, target, svg.FormatNumber(value))
+		}
+	case sketcher.ConstraintLineAngle:
+		d.angle(sketcher.AsLine(entities[0]), sketcher.AsLine(entities[1]), value)
+	case sketcher.ConstraintCurveDiameter:
+		d.diameter(entities[0], value)
+	}
+}
+
+func (d *svgDimensioner) line(class string, a, b [2]float64) {
+	path := svg.Path{Segments: []svg.Segment{&svg.Line{From: svgPoint(a[0], a[1]), To: svgPoint(b[0], b[1])}}}
+	d.elements = append(d.elements, &svg.Outline{Class: class, Path: path})
+}
+
+// label places text at a point in sketch coordinates, along the direction angle (radians) but never upside down
+func (d *svgDimensioner) label(at [2]float64, direction float64, text string) {
+	degrees := -utils.ToDegrees(direction)
+	for degrees > 90 {
+		degrees -= 180
+	}
+	for degrees <= -90 {
+		degrees += 180
+	}
+	d.elements = append(d.elements, &svg.Text{Class: "label", At: svgPoint(at[0], at[1]), Content: text, Angle: degrees})
+}
+
+// aligned draws a dimension parallel to the segment from a to b, offset to its left
+func (d *svgDimensioner) aligned(a, b [2]float64, text string) {
+	length := math.Hypot(b[0]-a[0], b[1]-a[1])
+	if length < 1e-9 {
+		return
+	}
+	u := [2]float64{(b[0] - a[0]) / length, (b[1] - a[1]) / length}
+	n := [2]float64{-u[1], u[0]}
+	offset := 2 * d.textHeight
+	at := func(p [2]float64, distance float64) [2]float64 {
+		return [2]float64{p[0] + n[0]*distance, p[1] + n[1]*distance}
+	}
+
+	d.line("dimension", a, at(a, offset+d.textHeight/2))
+	d.line("dimension", b, at(b, offset+d.textHeight/2))
+	d.line("dimension", at(a, offset), at(b, offset))
+	// Slashes mark where the dimension line meets the extension lines
+	tick := d.textHeight / 3
+	for _, p := range [][2]float64{at(a, offset), at(b, offset)} {
+		d.line("dimension",
+			[2]float64{p[0] - (u[0]+n[0])*tick, p[1] - (u[1]+n[1])*tick},
+			[2]float64{p[0] + (u[0]+n[0])*tick, p[1] + (u[1]+n[1])*tick})
+	}
+	middle := [2]float64{(a[0] + b[0]) / 2, (a[1] + b[1]) / 2}
+	d.label(at(middle, offset+d.textHeight), math.Atan2(u[1], u[0]), text)
+}
+
+// lineThrough returns two points on the line. The sketch axes have no endpoints.
+func (d *svgDimensioner) lineThrough(l *sketcher.Line) ([2]float64, [2]float64) {
+	if l.Start != nil {
+		return [2]float64{l.Start.X, l.Start.Y}, [2]float64{l.End.X, l.End.Y}
+	}
+	if l == d.sketch.YAxis() {
+		return [2]float64{0, 0}, [2]float64{0, 1}
+	}
+	return [2]float64{0, 0}, [2]float64{1, 0}
+}
+
+// project returns the closest point to p on the infinite line through a and b
+func project(p, a, b [2]float64) [2]float64 {
+	dx, dy := b[0]-a[0], b[1]-a[1]
+	t := ((p[0]-a[0])*dx + (p[1]-a[1])*dy) / (dx*dx + dy*dy)
+	return [2]float64{a[0] + t*dx, a[1] + t*dy}
+}
+
+// closestPoints returns the points a distance constraint between two entities measures between
+func (d *svgDimensioner) closestPoints(e1, e2 sketcher.Entity) ([2]float64, [2]float64, bool) {
+	if sketcher.AsPoint(e1) == nil && sketcher.AsPoint(e2) != nil {
+		e1, e2 = e2, e1
+	}
+	if p := sketcher.AsPoint(e1); p != nil {
+		point := [2]float64{p.X, p.Y}
+		switch o := e2.(type) {
+		case *sketcher.Point:
+			return point, [2]float64{o.X, o.Y}, true
+		case *sketcher.Line:
+			a, b := d.lineThrough(o)
+			return point, project(point, a, b), true
+		case *sketcher.Circle:
+			return point, pointOnCurve(point, o.Center, o.Radius), true
+		case *sketcher.Arc:
+			return point, pointOnCurve(point, o.Center, math.Hypot(o.Start.X-o.Center.X, o.Start.Y-o.Center.Y)), true
+		}
+		return point, point, false
+	}
+
+	l1, l2 := sketcher.AsLine(e1), sketcher.AsLine(e2)
+	if l1 == nil || l2 == nil {
+		return [2]float64{}, [2]float64{}, false
+	}
+	if l1.Start == nil {
+		l1, l2 = l2, l1
+	}
+	a1, b1 := d.lineThrough(l1)
+	a2, b2 := d.lineThrough(l2)
+	middle := [2]float64{(a1[0] + b1[0]) / 2, (a1[1] + b1[1]) / 2}
+	return middle, project(middle, a2, b2), true
+}
+
+// pointOnCurve returns the point of a circle closest to p
+func pointOnCurve(p [2]float64, center *sketcher.Point, radius float64) [2]float64 {
+	dx, dy := p[0]-center.X, p[1]-center.Y
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		return [2]float64{center.X + radius, center.Y}
+	}
+	return [2]float64{center.X + dx/length*radius, center.Y + dy/length*radius}
+}
+
+// axisTarget returns where a vertical or horizontal distance from p to e is measured to
+func (d *svgDimensioner) axisTarget(p *sketcher.Point, e sketcher.Entity, vertical bool) ([2]float64, bool) {
+	switch o := e.(type) {
+	case *sketcher.Point:
+		if vertical {
+			return [2]float64{p.X, o.Y}, true
+		}
+		return [2]float64{o.X, p.Y}, true
+	case *sketcher.Line:
+		a, b := d.lineThrough(o)
+		if vertical {
+			if math.Abs(b[0]-a[0]) < 1e-9 {
+				return [2]float64{}, false
+			}
+			return [2]float64{p.X, a[1] + (p.X-a[0])*(b[1]-a[1])/(b[0]-a[0])}, true
+		}
+		if math.Abs(b[1]-a[1]) < 1e-9 {
+			return [2]float64{}, false
+		}
+		return [2]float64{a[0] + (p.Y-a[1])*(b[0]-a[0])/(b[1]-a[1]), p.Y}, true
+	}
+	return [2]float64{}, false
+}
+
+// angle draws an arc between two lines around their intersection
+func (d *svgDimensioner) angle(l1, l2 *sketcher.Line, value float64) {
+	if l1 == nil || l2 == nil {
+		return
+	}
+	a1, b1 := d.lineThrough(l1)
+	a2, b2 := d.lineThrough(l2)
+	r := [2]float64{b1[0] - a1[0], b1[1] - a1[1]}
+	s := [2]float64{b2[0] - a2[0], b2[1] - a2[1]}
+	cross := r[0]*s[1] - r[1]*s[0]
+	if math.Abs(cross) < 1e-9 {
+		return
+	}
+	t := ((a2[0]-a1[0])*s[1] - (a2[1]-a1[1])*s[0]) / cross
+	vertex := [2]float64{a1[0] + t*r[0], a1[1] + t*r[1]}
+
+	start, end := math.Atan2(r[1], r[0]), math.Atan2(s[1], s[0])
+	sweep := math.Mod(end-start+4*math.Pi, 2*math.Pi)
+	if sweep > math.Pi {
+		start, sweep = end, 2*math.Pi-sweep
+	}
+	radius := 3 * d.textHeight
+	onArc := func(angle float64, distance float64) [2]float64 {
+		return [2]float64{vertex[0] + distance*math.Cos(angle), vertex[1] + distance*math.Sin(angle)}
+	}
+	from, to := onArc(start, radius), onArc(start+sweep, radius)
+	path := svg.Path{Segments: []svg.Segment{&svg.Arc{
+		From:   svgPoint(from[0], from[1]),
+		To:     svgPoint(to[0], to[1]),
+		Center: svgPoint(vertex[0], vertex[1]),
+		Radius: radius,
+		Sweep:  false,
+	}}}
+	d.elements = append(d.elements, &svg.Outline{Class: "dimension", Path: path})
+	middle := start + sweep/2
+	d.label(onArc(middle, radius+d.textHeight), middle-math.Pi/2, svg.FormatNumber(utils.ToDegrees(value))+"°")
+}
+
+// diameter draws a leader across a circle labelled with its diameter, or from an arc's center labelled with its radius
+func (d *svgDimensioner) diameter(e sketcher.Entity, value float64) {
+	direction := [2]float64{math.Sqrt2 / 2, math.Sqrt2 / 2}
+	switch o := e.(type) {
+	case *sketcher.Circle:
+		c := [2]float64{o.Center.X, o.Center.Y}
+		far := [2]float64{c[0] + direction[0]*(o.Radius+d.textHeight), c[1] + direction[1]*(o.Radius+d.textHeight)}
+		d.line("dimension", [2]float64{c[0] - direction[0]*o.Radius, c[1] - direction[1]*o.Radius}, far)
+		d.label([2]float64{far[0] + 2*d.textHeight, far[1]}, 0, "⌀"+svg.FormatNumber(value))
+	case *sketcher.Arc:
+		c := [2]float64{o.Center.X, o.Center.Y}
+		radius := math.Hypot(o.Start.X-c[0], o.Start.Y-c[1])
+		start := math.Atan2(o.Start.Y-c[1], o.Start.X-c[0])
+		end := math.Atan2(o.End.Y-c[1], o.End.X-c[0])
+		middle := start + math.Mod(end-start+4*math.Pi, 2*math.Pi)/2
+		direction = [2]float64{math.Cos(middle), math.Sin(middle)}
+		far := [2]float64{c[0] + direction[0]*(radius+d.textHeight), c[1] + direction[1]*(radius+d.textHeight)}
+		d.line("dimension", c, far)
+		d.label([2]float64{far[0] + direction[0]*2*d.textHeight, far[1] + direction[1]*2*d.textHeight}, 0, "R"+svg.FormatNumber(value/2))
+	}
+}
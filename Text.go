@@ -0,0 +1,111 @@
+package makercad
+
+import (
+	"errors"
+	"math"
+	"os"
+
+	"github.com/marcuswu/makercad/glyph"
+)
+
+// Text creates the outlines of text as closed loops of lines on the sketch using a TrueType or OpenType font file.
+// The baseline of the first line of text starts at the sketch origin. Height is the font size (em height) in sketch units.
+// Curves in the glyph outlines are approximated with line segments. Each glyph contour is a separate loop, so the sketch
+// is turned into faces with [NewFaces], or the returned loops with [NewFacesFromLoops]. [NewFace] makes a single face and
+// does not apply to text.
+func (s *Sketch) Text(text string, fontFile string, height float64) ([]Loop, error) {
+	contours, err := textContours(text, fontFile, height)
+	if err != nil {
+		return nil, err
+	}
+	return s.polylineLoops(contours), nil
+}
+
+// textContours reads the font file and returns each glyph contour of the text as a closed polyline in sketch coordinates
+func textContours(text string, fontFile string, height float64) ([][][2]float64, error) {
+	data, err := os.ReadFile(fontFile)
+	if err != nil {
+		return nil, err
+	}
+	return glyph.Contours(text, data, height)
+}
+
+// polylineLoops creates a fixed loop of lines on the sketch for each closed polyline
+func (s *Sketch) polylineLoops(polylines [][][2]float64) []Loop {
+	loops := make([]Loop, 0, len(polylines))
+	for _, polyline := range polylines {
+		loop := make(Loop, 0, len(polyline))
+		for i := range polyline {
+			start, end := polyline[i], polyline[(i+1)%len(polyline)]
+			line := s.solver.CreateLine(start[0], start[1], end[0], end[1])
+			s.solver.MakeFixed(line)
+			loop = append(loop, line)
+		}
+		if len(loop) > 0 {
+			loops = append(loops, loop)
+		}
+	}
+	return loops
+}
+
+// Emboss raises text on this face by depth and fuses it with the provided shapes. The text is centered on the face.
+func (f *Face) Emboss(text string, fontFile string, height float64, depth float64, list ListOfShape) (*CadOperation, error) {
+	return f.textMerging(text, fontFile, height, depth, MergeTypeAdd, list)
+}
+
+// Engrave cuts text into this face by depth, removing it from the provided shapes. The text is centered on the face.
+func (f *Face) Engrave(text string, fontFile string, height float64, depth float64, list ListOfShape) (*CadOperation, error) {
+	return f.textMerging(text, fontFile, height, -depth, MergeTypeRemove, list)
+}
+
+func (f *Face) textMerging(text string, fontFile string, height float64, depth float64, merge MergeType, list ListOfShape) (*CadOperation, error) {
+	if !f.IsPlanar() {
+		return nil, errors.New("cannot place text on non-planar face")
+	}
+	contours, err := textContours(text, fontFile, height)
+	if err != nil {
+		return nil, err
+	}
+	if len(contours) < 1 {
+		return nil, errors.New("text has no outlines")
+	}
+
+	// Center the text on the face
+	minX, minY, maxX, maxY := math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+	for _, contour := range contours {
+		for _, p := range contour {
+			minX, maxX = math.Min(minX, p[0]), math.Max(maxX, p[0])
+			minY, maxY = math.Min(minY, p[1]), math.Max(maxY, p[1])
+		}
+	}
+	for _, contour := range contours {
+		for i := range contour {
+			contour[i][0] -= (minX + maxX) / 2
+			contour[i][1] -= (minY + maxY) / 2
+		}
+	}
+
+	sketch := newSketch(f)
+	faces := NewFacesFromLoops(sketch.polylineLoops(contours))
+	normal := f.Normal()
+
+	var operation *CadOperation
+	shapes := list
+	for _, face := range faces {
+		// Glyph faces may be wound either way; extrude relative to this face's normal
+		distance := depth
+		if face.Normal().Dot(normal) < 0 {
+			distance = -depth
+		}
+		operation, err = face.ExtrudeMerging(distance, merge, shapes)
+		if err != nil {
+			return nil, err
+		}
+		shapes = ListOfShape{operation.Shape()}
+	}
+	if operation == nil {
+		return nil, errors.New("text produced no faces")
+	}
+
+	return operation, nil
+}
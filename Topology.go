@@ -0,0 +1,174 @@
+package makercad
+
+import (
+	"cmp"
+	"slices"
+	"sort"
+
+	"github.com/marcuswu/makercad/sketcher"
+
+	"github.com/marcuswu/gooccwrapper/topexp"
+)
+
+// sweepWindow is how far apart along their sort key two items may be and still be compared by a geometricIndex
+const sweepWindow = 1e-6
+
+// geometricIndex lists items without duplicates, where the wrapper can only tell items are the same by comparing them.
+// Items are sorted along a key coordinate, so an item is only compared with the few items with nearly the same key.
+type geometricIndex[T any] struct {
+	unique []T
+	keys   []float64
+	// sorted holds the indexes of unique sorted by key
+	sorted []int
+	key    func(T) float64
+	same   func(a, b T) bool
+}
+
+// newGeometricIndex indexes the items and returns the index of each item in the unique list. Unique items keep the
+// order they were first seen in.
+func newGeometricIndex[T any](items []T, key func(T) float64, same func(a, b T) bool) (*geometricIndex[T], []int) {
+	keys := make([]float64, len(items))
+	for i, item := range items {
+		keys[i] = key(item)
+	}
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int { return cmp.Compare(keys[a], keys[b]) })
+
+	// first holds the lowest index of an item which is the same as each item
+	first := make([]int, len(items))
+	for p, i := range order {
+		first[i] = i
+		for q := p - 1; q >= 0 && keys[i]-keys[order[q]] <= sweepWindow; q-- {
+			if j := order[q]; first[j] == j && same(items[i], items[j]) {
+				first[i] = min(i, j)
+				first[j] = first[i]
+				break
+			}
+		}
+	}
+
+	index := &geometricIndex[T]{key: key, same: same}
+	ids := make([]int, len(items))
+	uniqueIds := make(map[int]int)
+	for i := range items {
+		representative := first[i]
+		for first[representative] != representative {
+			representative = first[representative]
+		}
+		id, ok := uniqueIds[representative]
+		if !ok {
+			id = len(index.unique)
+			uniqueIds[representative] = id
+			index.unique = append(index.unique, items[representative])
+			index.keys = append(index.keys, keys[representative])
+		}
+		ids[i] = id
+	}
+	index.sorted = make([]int, len(index.unique))
+	for i := range index.sorted {
+		index.sorted[i] = i
+	}
+	slices.SortStableFunc(index.sorted, func(a, b int) int { return cmp.Compare(index.keys[a], index.keys[b]) })
+	return index, ids
+}
+
+// find returns the index of the unique item which is the same as item, or -1 if there is none
+func (x *geometricIndex[T]) find(item T) int {
+	key := x.key(item)
+	start := sort.Search(len(x.sorted), func(p int) bool { return x.keys[x.sorted[p]] >= key-sweepWindow })
+	for _, i := range x.sorted[start:] {
+		if x.keys[i] > key+sweepWindow {
+			break
+		}
+		if x.same(x.unique[i], item) {
+			return i
+		}
+	}
+	return -1
+}
+
+// topology indexes the faces, edges and vertices of a shape and how they meet
+type topology struct {
+	faces       ListOfFace
+	edges       *geometricIndex[*sketcher.Edge]
+	vertices    *geometricIndex[*sketcher.Vertex]
+	faceEdges   [][]int
+	edgeFaces   [][]int
+	vertexEdges [][]int
+}
+
+// edgeKey places an edge by the sum of the coordinates of its ends, which does not depend on the edge's orientation
+func edgeKey(e *sketcher.Edge) float64 {
+	first, last := e.FirstVertex(), e.LastVertex()
+	return first.X() + first.Y() + first.Z() + last.X() + last.Y() + last.Z()
+}
+
+func vertexKey(v *sketcher.Vertex) float64 {
+	point := v.ToPoint()
+	return point.X() + point.Y() + point.Z()
+}
+
+// topology builds the index of the shape's faces, edges and vertices
+func (s Shape) topology() *topology {
+	t := &topology{faces: s.Faces()}
+
+	// Edges are listed as the shape is explored, followed by each face's edges to find which faces they bound
+	edges := make(sketcher.ListOfEdge, 0)
+	for ex := topexp.NewExplorer(s.Shape, topexp.Edge); ex.More(); ex.Next() {
+		edges = append(edges, sketcher.NewEdgeFromRef(ex.Current()))
+	}
+	explored := len(edges)
+	faceOf := make([]int, 0)
+	for f, face := range t.faces {
+		for _, edge := range face.Edges() {
+			edges = append(edges, edge)
+			faceOf = append(faceOf, f)
+		}
+	}
+	var edgeIds []int
+	t.edges, edgeIds = newGeometricIndex(edges, edgeKey, (*sketcher.Edge).IsSame)
+	t.faceEdges = make([][]int, len(t.faces))
+	t.edgeFaces = make([][]int, len(t.edges.unique))
+	for i, f := range faceOf {
+		id := edgeIds[explored+i]
+		if !slices.Contains(t.edgeFaces[id], f) {
+			t.edgeFaces[id] = append(t.edgeFaces[id], f)
+			t.faceEdges[f] = append(t.faceEdges[f], id)
+		}
+	}
+
+	vertices := make(sketcher.ListOfVertex, 0)
+	for ex := topexp.NewExplorer(s.Shape, topexp.Vertex); ex.More(); ex.Next() {
+		vertices = append(vertices, sketcher.NewVertexFromRef(ex.Current()))
+	}
+	explored = len(vertices)
+	edgeOf := make([]int, 0)
+	for e, edge := range t.edges.unique {
+		for _, vertex := range edge.Vertexes() {
+			vertices = append(vertices, vertex)
+			edgeOf = append(edgeOf, e)
+		}
+	}
+	var vertexIds []int
+	t.vertices, vertexIds = newGeometricIndex(vertices, vertexKey, (*sketcher.Vertex).IsSame)
+	t.vertexEdges = make([][]int, len(t.vertices.unique))
+	for i, e := range edgeOf {
+		id := vertexIds[explored+i]
+		if !slices.Contains(t.vertexEdges[id], e) {
+			t.vertexEdges[id] = append(t.vertexEdges[id], e)
+		}
+	}
+	return t
+}
+
+// facesOf returns the faces with the indexes
+func (t *topology) facesOf(indexes []int) ListOfFace {
+	faces := make(ListOfFace, 0, len(indexes))
+	for _, i := range indexes {
+		faces = append(faces, t.faces[i])
+	}
+	return faces
+}
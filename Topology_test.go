@@ -0,0 +1,72 @@
+package makercad
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/marcuswu/makercad/mesh"
+)
+
+func TestGeometricIndex(t *testing.T) {
+	key := func(v mesh.Vector) float64 { return v.X + v.Y + v.Z }
+	same := func(a, b mesh.Vector) bool { return a.Sub(b).Length() < 1e-9 }
+	items := []mesh.Vector{
+		{X: 1},
+		{Y: 1}, // same key as the first but a different point
+		{},
+		{X: 1},
+		{Y: 1, Z: 1e-12},
+		{X: 2, Y: 2, Z: 2},
+	}
+	index, ids := newGeometricIndex(items, key, same)
+
+	if want := []int{0, 1, 2, 0, 1, 3}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("ids are %v, want %v", ids, want)
+	}
+	if want := []mesh.Vector{items[0], items[1], items[2], items[5]}; !reflect.DeepEqual(index.unique, want) {
+		t.Errorf("unique items are %v, want %v", index.unique, want)
+	}
+	for i, item := range index.unique {
+		if found := index.find(item); found != i {
+			t.Errorf("found %v at %d, want %d", item, found, i)
+		}
+	}
+	if found := index.find(mesh.Vector{Z: 1}); found != -1 {
+		t.Errorf("found a missing item at %d", found)
+	}
+}
+
+func TestTopology(t *testing.T) {
+	cad := NewMakerCad()
+	box := cad.MakeBox(cad.TopPlane, 1, 2, 3, false)
+
+	if edges := box.Edges(); len(edges) != 12 {
+		t.Errorf("box has %d edges, want 12", len(edges))
+	}
+	if vertices := box.Vertices(); len(vertices) != 8 {
+		t.Errorf("box has %d vertices, want 8", len(vertices))
+	}
+	for _, edge := range box.Edges() {
+		if faces := box.FacesOfEdge(edge); len(faces) != 2 {
+			t.Errorf("edge meets %d faces, want 2", len(faces))
+		}
+	}
+	for _, vertex := range box.Vertices() {
+		if edges := box.EdgesOfVertex(vertex); len(edges) != 3 {
+			t.Errorf("vertex meets %d edges, want 3", len(edges))
+		}
+	}
+	for _, face := range box.Faces() {
+		adjacent := box.AdjacentFaces(face)
+		if len(adjacent) != 4 {
+			t.Errorf("face has %d adjacent faces, want 4", len(adjacent))
+		}
+		for _, other := range adjacent {
+			// Adjacent faces of a box are perpendicular
+			if math.Abs(other.Normal().Dot(face.Normal())) > 1e-9 {
+				t.Errorf("adjacent face is not perpendicular")
+			}
+		}
+	}
+}
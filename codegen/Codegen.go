@@ -0,0 +1,232 @@
+// Package codegen generates MakerCAD Go programs from a list of features and reads the feature list back from them.
+// Every feature is written with an annotation holding its definition and the file carries a checksum of its contents
+// so tools can tell when generated code was edited by hand.
+package codegen
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/format"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	header            = "// Code generated by MakerCAD. DO NOT EDIT."
+	checksumPrefix    = "// makercad:checksum "
+	annotationPrefix  = "// makercad:feature "
+	defaultEdges      = "shape.Edges()"
+	defaultRevolution = "sketch.XAxis()"
+)
+
+// ErrChecksumMismatch is returned by [Parse] when the source no longer matches its checksum, meaning it was edited after generation
+var ErrChecksumMismatch = errors.New("generated code was modified after generation")
+
+// Feature describes one step of a model. Type is the name of a makercad.FeatureType: Sketch, Extrude, Revolve, Boolean, Fillet or Chamfer.
+//
+// Options configure each type:
+//   - Sketch: "sketch" holds the sketch serialized with makercad's Sketch.MarshalJSON
+//   - Revolve: "axis" is a Go expression choosing the axis from the variable sketch (defaults to sketch.XAxis())
+//   - Boolean: "merge" is "add" or "remove". The first dependency is the target and the rest are tools.
+//   - Fillet and Chamfer: "edges" is a Go expression choosing the edges from the variable shape (defaults to all edges)
+type Feature struct {
+	Name         string             `json:"name"`
+	Type         string             `json:"type"`
+	Params       map[string]float64 `json:"params,omitempty"`
+	Dependencies []string           `json:"dependencies,omitempty"`
+	Suppressed   bool               `json:"suppressed,omitempty"`
+	Options      map[string]string  `json:"options,omitempty"`
+}
+
+// Generate returns a formatted Go program which builds the features as a makercad.Model and exports the last shape to output as STEP
+func Generate(features []Feature, output string) ([]byte, error) {
+	var body bytes.Buffer
+	body.WriteString("func main() {\n\tcad := makercad.NewMakerCad()\n\tmodel := makercad.NewModel(cad)\n\n")
+
+	names := make([]string, 0, len(features))
+	last := ""
+	for _, feature := range features {
+		if slices.Contains(names, feature.Name) {
+			return nil, fmt.Errorf("duplicate feature %q", feature.Name)
+		}
+		for _, dependency := range feature.Dependencies {
+			if !slices.Contains(names, dependency) {
+				return nil, fmt.Errorf("feature %q depends on %q which is not defined before it", feature.Name, dependency)
+			}
+		}
+		names = append(names, feature.Name)
+
+		annotation, err := json.Marshal(feature)
+		if err != nil {
+			return nil, err
+		}
+		code, err := featureCode(feature)
+		if err != nil {
+			return nil, fmt.Errorf("feature %q: %w", feature.Name, err)
+		}
+		body.WriteString("\t" + annotationPrefix + string(annotation) + "\n")
+		body.WriteString("\tmust(" + code + ")\n")
+		if feature.Suppressed {
+			fmt.Fprintf(&body, "\tif err := model.Suppress(%s, true); err != nil {\n\t\tlog.Fatal(err)\n\t}\n", strconv.Quote(feature.Name))
+		}
+		body.WriteString("\n")
+		if feature.Type != "Sketch" {
+			last = feature.Name
+		}
+	}
+
+	body.WriteString("\tif err := model.Rebuild(); err != nil {\n\t\tlog.Fatal(err)\n\t}\n")
+	if last != "" {
+		fmt.Fprintf(&body, "\tshape, err := model.Shape(%s)\n\tif err != nil {\n\t\tlog.Fatal(err)\n\t}\n", strconv.Quote(last))
+		fmt.Fprintf(&body, "\tif err := cad.ExportStep(%s, makercad.ListOfShape{shape}); err != nil {\n\t\tlog.Fatal(err)\n\t}\n", strconv.Quote(output))
+	}
+	body.WriteString("}\n\n")
+	body.WriteString("func must(_ *makercad.Feature, err error) {\n\tif err != nil {\n\t\tlog.Fatal(err)\n\t}\n}\n")
+
+	imports := "import (\n\t\"log\"\n\n\t\"github.com/marcuswu/makercad\"\n"
+	if bytes.Contains(body.Bytes(), []byte("sketcher.")) {
+		imports += "\t\"github.com/marcuswu/makercad/sketcher\"\n"
+	}
+	program := append([]byte("package main\n\n"+imports+")\n\n"), body.Bytes()...)
+	formatted, err := format.Source(program)
+	if err != nil {
+		return nil, fmt.Errorf("generated code is not valid Go syntax: %w", err)
+	}
+
+	var source bytes.Buffer
+	source.WriteString(header + "\n")
+	source.WriteString(checksumPrefix + checksum(formatted) + "\n\n")
+	source.Write(formatted)
+	return source.Bytes(), nil
+}
+
+// featureCode returns the Go expression adding the feature to the model
+func featureCode(feature Feature) (string, error) {
+	name := strconv.Quote(feature.Name)
+	param := func(key string) (string, error) {
+		value, ok := feature.Params[key]
+		if !ok {
+			return "", fmt.Errorf("missing %q parameter", key)
+		}
+		return strconv.FormatFloat(value, 'g', -1, 64), nil
+	}
+	option := func(key string, fallback string) string {
+		if value, ok := feature.Options[key]; ok && value != "" {
+			return value
+		}
+		return fallback
+	}
+	dependencies := func(count int) error {
+		if len(feature.Dependencies) < count {
+			return fmt.Errorf("%s features require %d dependencies", feature.Type, count)
+		}
+		return nil
+	}
+
+	switch feature.Type {
+	case "Sketch":
+		sketch, ok := feature.Options["sketch"]
+		if !ok {
+			return "", errors.New("missing sketch option")
+		}
+		literal := "`" + sketch + "`"
+		if strings.Contains(sketch, "`") {
+			literal = strconv.Quote(sketch)
+		}
+		return fmt.Sprintf("model.Add(%s, makercad.FeatureSketch, nil, nil, func(makercad.Params, []makercad.FeatureResult) (makercad.FeatureResult, error) {\n"+
+			"sketch, err := makercad.UnmarshalSketch([]byte(%s))\n"+
+			"return makercad.FeatureResult{Sketch: sketch}, err\n})", name, literal), nil
+	case "Extrude":
+		distance, err := param("distance")
+		if err != nil {
+			return "", err
+		}
+		if err := dependencies(1); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("model.AddExtrude(%s, %s, %s)", name, strconv.Quote(feature.Dependencies[0]), distance), nil
+	case "Revolve":
+		angle, err := param("angle")
+		if err != nil {
+			return "", err
+		}
+		if err := dependencies(1); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("model.AddRevolve(%s, %s, func(sketch *makercad.Sketch) *sketcher.Line { return %s }, %s)",
+			name, strconv.Quote(feature.Dependencies[0]), option("axis", defaultRevolution), angle), nil
+	case "Boolean":
+		if err := dependencies(2); err != nil {
+			return "", err
+		}
+		merge := map[string]string{"add": "makercad.MergeTypeAdd", "remove": "makercad.MergeTypeRemove"}[feature.Options["merge"]]
+		if merge == "" {
+			return "", errors.New(`merge option must be "add" or "remove"`)
+		}
+		quoted := make([]string, 0, len(feature.Dependencies))
+		for _, dependency := range feature.Dependencies {
+			quoted = append(quoted, strconv.Quote(dependency))
+		}
+		return fmt.Sprintf("model.AddBoolean(%s, %s, %s)", name, merge, strings.Join(quoted, ", ")), nil
+	case "Fillet", "Chamfer":
+		key := "radius"
+		if feature.Type == "Chamfer" {
+			key = "distance"
+		}
+		value, err := param(key)
+		if err != nil {
+			return "", err
+		}
+		if err := dependencies(1); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("model.Add%s(%s, %s, %s, func(shape makercad.Shape) sketcher.ListOfEdge { return %s })",
+			feature.Type, name, strconv.Quote(feature.Dependencies[0]), value, option("edges", defaultEdges)), nil
+	}
+	return "", fmt.Errorf("unsupported feature type %q", feature.Type)
+}
+
+func checksum(body []byte) string {
+	sum := sha256.Sum256(body)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// Parse reads the features back from a program written by [Generate]. If the code was edited after it was
+// generated, the features are returned along with [ErrChecksumMismatch] so the caller can warn about it.
+func Parse(source []byte) ([]Feature, error) {
+	headerLine, rest, _ := bytes.Cut(source, []byte("\n"))
+	checksumLine, body, _ := bytes.Cut(rest, []byte("\n"))
+	body = bytes.TrimPrefix(body, []byte("\n"))
+	if strings.TrimSpace(string(headerLine)) != header || !strings.HasPrefix(string(checksumLine), checksumPrefix) {
+		return nil, errors.New("source was not generated by MakerCAD")
+	}
+
+	features := make([]Feature, 0)
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(body)+1)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(text, annotationPrefix) {
+			continue
+		}
+		var feature Feature
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(text, annotationPrefix)), &feature); err != nil {
+			return nil, fmt.Errorf("line %d: invalid feature annotation: %w", line+3, err)
+		}
+		features = append(features, feature)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if strings.TrimPrefix(string(checksumLine), checksumPrefix) != checksum(body) {
+		return features, ErrChecksumMismatch
+	}
+	return features, nil
+}
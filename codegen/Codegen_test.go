@@ -0,0 +1,115 @@
+package codegen
+
+import (
+	"bytes"
+	"errors"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"testing"
+)
+
+func features() []Feature {
+	return []Feature{
+		{Name: "base", Type: "Sketch", Options: map[string]string{"sketch": `{"plane":"xy","entities":[]}`}},
+		{Name: "quoted", Type: "Sketch", Options: map[string]string{"sketch": "{\"note\":\"`tick`\"}"}},
+		{Name: "body", Type: "Extrude", Params: map[string]float64{"distance": 10}, Dependencies: []string{"base"}},
+		{Name: "turned", Type: "Revolve", Params: map[string]float64{"angle": 3.14159}, Dependencies: []string{"quoted"},
+			Options: map[string]string{"axis": "sketch.YAxis()"}},
+		{Name: "joined", Type: "Boolean", Dependencies: []string{"body", "turned"}, Options: map[string]string{"merge": "add"}},
+		{Name: "rounded", Type: "Fillet", Params: map[string]float64{"radius": 0.5}, Dependencies: []string{"joined"}, Suppressed: true},
+		{Name: "edged", Type: "Chamfer", Params: map[string]float64{"distance": 0.25}, Dependencies: []string{"joined"},
+			Options: map[string]string{"edges": "shape.Edges().IsLine()"}},
+	}
+}
+
+func TestGenerateParse(t *testing.T) {
+	source, err := Generate(features(), "model.step")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "main.go", source, 0); err != nil {
+		t.Fatalf("generated code does not parse: %v\n%s", err, source)
+	}
+
+	parsed, err := Parse(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(parsed, features()) {
+		t.Errorf("parsed %+v, want %+v", parsed, features())
+	}
+
+	again, err := Generate(parsed, "model.step")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(source, again) {
+		t.Errorf("generating the parsed features differs:\n%s\n%s", source, again)
+	}
+}
+
+func TestParseEdited(t *testing.T) {
+	source, err := Generate(features(), "model.step")
+	if err != nil {
+		t.Fatal(err)
+	}
+	edited := bytes.Replace(source, []byte(`"model.step"`), []byte(`"edited.step"`), 1)
+	if bytes.Equal(source, edited) {
+		t.Fatal("edit did not change the source")
+	}
+
+	parsed, err := Parse(edited)
+	if !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("got error %v, want %v", err, ErrChecksumMismatch)
+	}
+	if !reflect.DeepEqual(parsed, features()) {
+		t.Errorf("parsed %+v, want %+v", parsed, features())
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	source, err := Generate(features(), "model.step")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		source []byte
+	}{
+		{"not generated", []byte("package main\n\nfunc main() {}\n")},
+		{"invalid annotation", bytes.Replace(source, []byte(annotationPrefix+"{"), []byte(annotationPrefix+"{,"), 1)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := Parse(test.source); err == nil || errors.Is(err, ErrChecksumMismatch) {
+				t.Errorf("got error %v, want a parse error", err)
+			}
+		})
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	sketch := Feature{Name: "base", Type: "Sketch", Options: map[string]string{"sketch": "{}"}}
+	tests := []struct {
+		name     string
+		features []Feature
+	}{
+		{"duplicate name", []Feature{sketch, sketch}},
+		{"undefined dependency", []Feature{{Name: "body", Type: "Extrude", Params: map[string]float64{"distance": 1}, Dependencies: []string{"base"}}}},
+		{"missing parameter", []Feature{sketch, {Name: "body", Type: "Extrude", Dependencies: []string{"base"}}}},
+		{"missing dependency", []Feature{{Name: "body", Type: "Extrude", Params: map[string]float64{"distance": 1}}}},
+		{"missing sketch", []Feature{{Name: "base", Type: "Sketch"}}},
+		{"invalid merge", []Feature{sketch, {Name: "b", Type: "Boolean", Dependencies: []string{"base", "base"}, Options: map[string]string{"merge": "xor"}}}},
+		{"unsupported type", []Feature{{Name: "loft", Type: "Loft"}}},
+		{"invalid expression", []Feature{sketch, {Name: "r", Type: "Fillet", Params: map[string]float64{"radius": 1}, Dependencies: []string{"base"},
+			Options: map[string]string{"edges": "shape.Edges("}}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := Generate(test.features, "model.step"); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+// Package dxf reads and writes the 2D entities of DXF drawings used to exchange outlines with other CAD and CAM software
+package dxf
+
+import "math"
+
+// Units is the drawing unit stored in the $INSUNITS header variable
+type Units int
+
+const (
+	UnitsUnitless   Units = 0
+	UnitsInches     Units = 1
+	UnitsFeet       Units = 2
+	UnitsMillimeter Units = 4
+	UnitsCentimeter Units = 5
+	UnitsMeter      Units = 6
+)
+
+// Point is a 2D coordinate in the drawing
+type Point struct {
+	X float64
+	Y float64
+}
+
+// Entity is any drawing entity. Every entity belongs to a layer.
+type Entity interface {
+	EntityLayer() string
+}
+
+// Drawing is the set of entities read from or written to a DXF file
+type Drawing struct {
+	Units    Units
+	Entities []Entity
+}
+
+// Line is a DXF LINE entity
+type Line struct {
+	Layer string
+	Start Point
+	End   Point
+}
+
+// Arc is a DXF ARC entity. It runs counterclockwise from StartAngle to EndAngle, both in degrees.
+type Arc struct {
+	Layer      string
+	Center     Point
+	Radius     float64
+	StartAngle float64
+	EndAngle   float64
+}
+
+// Circle is a DXF CIRCLE entity
+type Circle struct {
+	Layer  string
+	Center Point
+	Radius float64
+}
+
+// PolylineVertex is a vertex of a [Polyline]. Bulge is the tangent of a quarter of the included angle of the arc
+// to the next vertex, positive when counterclockwise. A bulge of zero is a straight segment.
+type PolylineVertex struct {
+	Point
+	Bulge float64
+}
+
+// Polyline is a DXF LWPOLYLINE entity
+type Polyline struct {
+	Layer    string
+	Vertices []PolylineVertex
+	Closed   bool
+}
+
+// Ellipse is a DXF ELLIPSE entity. MajorAxis is the endpoint of the major axis relative to the center and Ratio is
+// the length of the minor axis relative to the major axis. The parameters are angles in radians; a full ellipse runs from 0 to 2π.
+type Ellipse struct {
+	Layer      string
+	Center     Point
+	MajorAxis  Point
+	Ratio      float64
+	StartParam float64
+	EndParam   float64
+}
+
+// Spline is a DXF SPLINE entity. Splines are defined either by control points and knots or only by fit points.
+type Spline struct {
+	Layer         string
+	Degree        int
+	Closed        bool
+	Knots         []float64
+	Weights       []float64
+	ControlPoints []Point
+	FitPoints     []Point
+}
+
+func (l *Line) EntityLayer() string     { return l.Layer }
+func (a *Arc) EntityLayer() string      { return a.Layer }
+func (c *Circle) EntityLayer() string   { return c.Layer }
+func (p *Polyline) EntityLayer() string { return p.Layer }
+func (e *Ellipse) EntityLayer() string  { return e.Layer }
+func (s *Spline) EntityLayer() string   { return s.Layer }
+
+// IsCircular returns whether the ellipse has equal axes
+func (e *Ellipse) IsCircular() bool {
+	return math.Abs(e.Ratio-1) < 1e-9
+}
+
+// IsFull returns whether the ellipse is closed rather than an elliptical arc
+func (e *Ellipse) IsFull() bool {
+	return math.Abs(e.sweep()-2*math.Pi) < 1e-9
+}
+
+func (e *Ellipse) sweep() float64 {
+	sweep := e.EndParam - e.StartParam
+	for sweep <= 0 {
+		sweep += 2 * math.Pi
+	}
+	return sweep
+}
+
+// Points approximates the ellipse with segments straight segments, returning segments+1 points from start to end
+func (e *Ellipse) Points(segments int) []Point {
+	major := math.Hypot(e.MajorAxis.X, e.MajorAxis.Y)
+	rotation := math.Atan2(e.MajorAxis.Y, e.MajorAxis.X)
+	minor := major * e.Ratio
+	sweep := e.sweep()
+	points := make([]Point, 0, segments+1)
+	for i := 0; i <= segments; i++ {
+		param := e.StartParam + sweep*float64(i)/float64(segments)
+		x, y := major*math.Cos(param), minor*math.Sin(param)
+		points = append(points, Point{
+			e.Center.X + x*math.Cos(rotation) - y*math.Sin(rotation),
+			e.Center.Y + x*math.Sin(rotation) + y*math.Cos(rotation),
+		})
+	}
+	return points
+}
+
+// Points approximates the spline with straight segments, returning segments+1 points along the curve.
+// Splines without control points are approximated by their fit points.
+func (s *Spline) Points(segments int) []Point {
+	degree := s.Degree
+	count := len(s.ControlPoints)
+	if count == 0 || degree < 1 || len(s.Knots) != count+degree+1 {
+		points := append([]Point{}, s.FitPoints...)
+		if s.Closed && len(points) > 0 {
+			points = append(points, points[0])
+		}
+		return points
+	}
+
+	start, end := s.Knots[degree], s.Knots[count]
+	points := make([]Point, 0, segments+1)
+	for i := 0; i <= segments; i++ {
+		points = append(points, s.evaluate(start+(end-start)*float64(i)/float64(segments)))
+	}
+	return points
+}
+
+// evaluate returns the point on the spline at parameter t using de Boor's algorithm on homogeneous coordinates
+func (s *Spline) evaluate(t float64) Point {
+	degree := s.Degree
+	count := len(s.ControlPoints)
+
+	// Find the knot span containing t
+	span := degree
+	for span < count-1 && t >= s.Knots[span+1] {
+		span++
+	}
+
+	weight := func(i int) float64 {
+		if i < len(s.Weights) && s.Weights[i] > 0 {
+			return s.Weights[i]
+		}
+		return 1
+	}
+	d := make([][3]float64, degree+1)
+	for j := 0; j <= degree; j++ {
+		i := span - degree + j
+		w := weight(i)
+		d[j] = [3]float64{s.ControlPoints[i].X * w, s.ControlPoints[i].Y * w, w}
+	}
+	for r := 1; r <= degree; r++ {
+		for j := degree; j >= r; j-- {
+			i := span - degree + j
+			denominator := s.Knots[i+degree-r+1] - s.Knots[i]
+			alpha := 0.0
+			if denominator != 0 {
+				alpha = (t - s.Knots[i]) / denominator
+			}
+			for k := range d[j] {
+				d[j][k] = (1-alpha)*d[j-1][k] + alpha*d[j][k]
+			}
+		}
+	}
+	return Point{d[degree][0] / d[degree][2], d[degree][1] / d[degree][2]}
+}
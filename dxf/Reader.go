@@ -0,0 +1,309 @@
+package dxf
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// group is a single group code and value pair from a DXF file. Values of numeric group codes are parsed as they are
+// read.
+type group struct {
+	code   int
+	value  string
+	number float64
+}
+
+func (g group) float() float64 {
+	return g.number
+}
+
+func (g group) int() int {
+	return int(g.number)
+}
+
+// isNumeric returns whether the group code's value is an integer or a floating point number
+func isNumeric(code int) bool {
+	switch {
+	case code >= 10 && code <= 79, code >= 90 && code <= 99, code >= 110 && code <= 179, code >= 210 && code <= 289:
+		return true
+	case code >= 370 && code <= 409, code >= 420 && code <= 449, code >= 1010 && code <= 1071:
+		return true
+	}
+	return false
+}
+
+type groupReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func (r *groupReader) next() (group, error) {
+	if !r.scanner.Scan() {
+		return group{}, r.eof()
+	}
+	r.line++
+	code, err := strconv.Atoi(strings.TrimSpace(r.scanner.Text()))
+	if err != nil {
+		return group{}, fmt.Errorf("line %d: invalid group code %q", r.line, r.scanner.Text())
+	}
+	if !r.scanner.Scan() {
+		return group{}, r.eof()
+	}
+	r.line++
+	g := group{code: code, value: strings.TrimSpace(r.scanner.Text())}
+	if isNumeric(code) {
+		if g.number, err = strconv.ParseFloat(g.value, 64); err != nil {
+			return group{}, fmt.Errorf("line %d: invalid number %q for group code %d", r.line, g.value, code)
+		}
+	}
+	return g, nil
+}
+
+func (r *groupReader) eof() error {
+	if err := r.scanner.Err(); err != nil {
+		return err
+	}
+	return io.ErrUnexpectedEOF
+}
+
+// Read parses the header units and the supported entities of an ASCII DXF file. Other entity types are ignored.
+func Read(reader io.Reader) (*Drawing, error) {
+	r := &groupReader{scanner: bufio.NewScanner(reader)}
+	drawing := &Drawing{Entities: make([]Entity, 0)}
+
+	for {
+		g, err := r.next()
+		if err != nil {
+			return nil, err
+		}
+		if g.code != 0 {
+			continue
+		}
+		switch g.value {
+		case "EOF":
+			return drawing, nil
+		case "SECTION":
+			name, err := r.next()
+			if err != nil {
+				return nil, err
+			}
+			switch name.value {
+			case "HEADER":
+				err = r.readHeader(drawing)
+			case "ENTITIES":
+				err = r.readEntities(drawing)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+func (r *groupReader) readHeader(drawing *Drawing) error {
+	variable := ""
+	for {
+		g, err := r.next()
+		if err != nil {
+			return err
+		}
+		switch {
+		case g.code == 0 && g.value == "ENDSEC":
+			return nil
+		case g.code == 9:
+			variable = g.value
+		case g.code == 70 && variable == "$INSUNITS":
+			drawing.Units = Units(g.int())
+		}
+	}
+}
+
+func (r *groupReader) readEntities(drawing *Drawing) error {
+	g, err := r.next()
+	if err != nil {
+		return err
+	}
+	for {
+		if g.code != 0 {
+			return fmt.Errorf("line %d: expected entity start", r.line)
+		}
+		if g.value == "ENDSEC" {
+			return nil
+		}
+		entityType := g.value
+		groups := make([]group, 0)
+		for {
+			g, err = r.next()
+			if err != nil {
+				return err
+			}
+			if g.code == 0 {
+				break
+			}
+			groups = append(groups, g)
+		}
+		entity, err := parseEntity(entityType, groups)
+		if err != nil {
+			return err
+		}
+		if entity != nil {
+			drawing.Entities = append(drawing.Entities, entity)
+		}
+	}
+}
+
+func parseEntity(entityType string, groups []group) (Entity, error) {
+	layer := "0"
+	extrusionZ := 1.0
+	for _, g := range groups {
+		switch g.code {
+		case 8:
+			layer = g.value
+		case 230:
+			extrusionZ = g.float()
+		}
+	}
+	// Entities with a flipped extrusion direction mirror their object coordinates across the Y axis. ELLIPSE and SPLINE
+	// coordinates are in world coordinates already.
+	mirrored := extrusionZ < 0
+
+	switch entityType {
+	case "LINE":
+		line := &Line{Layer: layer}
+		for _, g := range groups {
+			switch g.code {
+			case 10:
+				line.Start.X = g.float()
+			case 20:
+				line.Start.Y = g.float()
+			case 11:
+				line.End.X = g.float()
+			case 21:
+				line.End.Y = g.float()
+			}
+		}
+		return line, nil
+	case "ARC":
+		arc := &Arc{Layer: layer}
+		for _, g := range groups {
+			switch g.code {
+			case 10:
+				arc.Center.X = g.float()
+			case 20:
+				arc.Center.Y = g.float()
+			case 40:
+				arc.Radius = g.float()
+			case 50:
+				arc.StartAngle = g.float()
+			case 51:
+				arc.EndAngle = g.float()
+			}
+		}
+		if mirrored {
+			arc.Center.X = -arc.Center.X
+			arc.StartAngle, arc.EndAngle = 180-arc.EndAngle, 180-arc.StartAngle
+		}
+		return arc, nil
+	case "CIRCLE":
+		circle := &Circle{Layer: layer}
+		for _, g := range groups {
+			switch g.code {
+			case 10:
+				circle.Center.X = g.float()
+			case 20:
+				circle.Center.Y = g.float()
+			case 40:
+				circle.Radius = g.float()
+			}
+		}
+		if mirrored {
+			circle.Center.X = -circle.Center.X
+		}
+		return circle, nil
+	case "LWPOLYLINE":
+		polyline := &Polyline{Layer: layer, Vertices: make([]PolylineVertex, 0)}
+		for _, g := range groups {
+			switch g.code {
+			case 70:
+				polyline.Closed = g.int()&1 != 0
+			case 10:
+				polyline.Vertices = append(polyline.Vertices, PolylineVertex{Point: Point{X: g.float()}})
+			case 20:
+				if len(polyline.Vertices) > 0 {
+					polyline.Vertices[len(polyline.Vertices)-1].Y = g.float()
+				}
+			case 42:
+				if len(polyline.Vertices) > 0 {
+					polyline.Vertices[len(polyline.Vertices)-1].Bulge = g.float()
+				}
+			}
+		}
+		if mirrored {
+			for i := range polyline.Vertices {
+				polyline.Vertices[i].X = -polyline.Vertices[i].X
+				polyline.Vertices[i].Bulge = -polyline.Vertices[i].Bulge
+			}
+		}
+		return polyline, nil
+	case "ELLIPSE":
+		ellipse := &Ellipse{Layer: layer, Ratio: 1}
+		for _, g := range groups {
+			switch g.code {
+			case 10:
+				ellipse.Center.X = g.float()
+			case 20:
+				ellipse.Center.Y = g.float()
+			case 11:
+				ellipse.MajorAxis.X = g.float()
+			case 21:
+				ellipse.MajorAxis.Y = g.float()
+			case 40:
+				ellipse.Ratio = g.float()
+			case 41:
+				ellipse.StartParam = g.float()
+			case 42:
+				ellipse.EndParam = g.float()
+			}
+		}
+		// The center and major axis are in world coordinates. Only the direction the parameters run in is flipped.
+		if mirrored {
+			ellipse.StartParam, ellipse.EndParam = -ellipse.EndParam, -ellipse.StartParam
+		}
+		return ellipse, nil
+	case "SPLINE":
+		spline := &Spline{Layer: layer}
+		for _, g := range groups {
+			switch g.code {
+			case 70:
+				spline.Closed = g.int()&1 != 0
+			case 71:
+				spline.Degree = g.int()
+			case 40:
+				spline.Knots = append(spline.Knots, g.float())
+			case 41:
+				spline.Weights = append(spline.Weights, g.float())
+			case 10:
+				spline.ControlPoints = append(spline.ControlPoints, Point{X: g.float()})
+			case 20:
+				if len(spline.ControlPoints) > 0 {
+					spline.ControlPoints[len(spline.ControlPoints)-1].Y = g.float()
+				}
+			case 11:
+				spline.FitPoints = append(spline.FitPoints, Point{X: g.float()})
+			case 21:
+				if len(spline.FitPoints) > 0 {
+					spline.FitPoints[len(spline.FitPoints)-1].Y = g.float()
+				}
+			}
+		}
+		if len(spline.ControlPoints) == 0 && len(spline.FitPoints) == 0 {
+			return nil, errors.New("spline has no control or fit points")
+		}
+		return spline, nil
+	}
+	return nil, nil
+}
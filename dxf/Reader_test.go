@@ -0,0 +1,136 @@
+package dxf
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// entities returns a DXF file with an ENTITIES section holding the lines given as alternating group codes and values
+func entities(lines ...string) string {
+	return strings.Join(append(append([]string{"0", "SECTION", "2", "ENTITIES"}, lines...), "0", "ENDSEC", "0", "EOF"), "\n")
+}
+
+func TestReadEntities(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		want Entity
+	}{
+		{
+			name: "line",
+			file: entities("0", "LINE", "8", "cut", "10", "1", "20", "2", "11", "3", "21", "4"),
+			want: &Line{Layer: "cut", Start: Point{1, 2}, End: Point{3, 4}},
+		},
+		{
+			name: "arc with flipped extrusion",
+			file: entities("0", "ARC", "10", "5", "20", "1", "40", "2", "50", "0", "51", "90", "230", "-1"),
+			want: &Arc{Layer: "0", Center: Point{-5, 1}, Radius: 2, StartAngle: 90, EndAngle: 180},
+		},
+		{
+			name: "polyline with bulge arc",
+			file: entities("0", "LWPOLYLINE", "90", "3", "70", "1", "10", "0", "20", "0", "42", "1", "10", "10", "20", "0", "10", "10", "20", "5"),
+			want: &Polyline{Layer: "0", Closed: true, Vertices: []PolylineVertex{{Point{0, 0}, 1}, {Point{10, 0}, 0}, {Point{10, 5}, 0}}},
+		},
+		{
+			name: "polyline with bulge arc and flipped extrusion",
+			file: entities("0", "LWPOLYLINE", "90", "2", "70", "0", "10", "1", "20", "0", "42", "0.5", "10", "3", "20", "0", "230", "-1"),
+			want: &Polyline{Layer: "0", Vertices: []PolylineVertex{{Point{-1, 0}, -0.5}, {Point{-3, 0}, 0}}},
+		},
+		{
+			name: "ellipse with flipped extrusion keeps world coordinates",
+			file: entities("0", "ELLIPSE", "10", "5", "20", "1", "11", "4", "21", "0", "40", "0.5", "41", "0", "42", "1.5", "230", "-1"),
+			want: &Ellipse{Layer: "0", Center: Point{5, 1}, MajorAxis: Point{4, 0}, Ratio: 0.5, StartParam: -1.5, EndParam: 0},
+		},
+		{
+			name: "unsupported entity",
+			file: entities("0", "TEXT", "1", "hello"),
+			want: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			drawing, err := Read(strings.NewReader(test.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if test.want == nil {
+				if len(drawing.Entities) != 0 {
+					t.Fatalf("got %d entities, want none", len(drawing.Entities))
+				}
+				return
+			}
+			if len(drawing.Entities) != 1 {
+				t.Fatalf("got %d entities, want 1", len(drawing.Entities))
+			}
+			if !reflect.DeepEqual(drawing.Entities[0], test.want) {
+				t.Errorf("got %+v, want %+v", drawing.Entities[0], test.want)
+			}
+		})
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+	}{
+		{"truncated", "0\nSECTION\n2\nENTITIES\n0\nLINE\n10"},
+		{"invalid group code", "x\nSECTION"},
+		{"spline without points", entities("0", "SPLINE", "71", "3")},
+		{"invalid coordinate", entities("0", "LINE", "10", "1,5", "20", "0", "11", "2", "21", "0")},
+		{"invalid flags", entities("0", "LWPOLYLINE", "70", "closed", "10", "0", "20", "0")},
+		{"invalid units", "0\nSECTION\n2\nHEADER\n9\n$INSUNITS\n70\nmm\n0\nENDSEC\n0\nEOF"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := Read(strings.NewReader(test.file)); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestWriteRead(t *testing.T) {
+	drawing := &Drawing{Units: UnitsMillimeter, Entities: []Entity{
+		&Line{Layer: "cut", Start: Point{0, 0}, End: Point{10, 0}},
+		&Arc{Layer: "cut", Center: Point{5, 5}, Radius: 2.5, StartAngle: 0, EndAngle: 270},
+		&Circle{Layer: "holes", Center: Point{-1, 2}, Radius: 0.75},
+		&Polyline{Layer: "cut", Closed: true, Vertices: []PolylineVertex{{Point{0, 0}, 0}, {Point{4, 0}, -1}, {Point{4, 4}, 0}}},
+		&Ellipse{Layer: "0", Center: Point{1, 1}, MajorAxis: Point{0, 3}, Ratio: 0.25, StartParam: 0, EndParam: 2 * math.Pi},
+	}}
+	var buffer bytes.Buffer
+	if err := Write(&buffer, drawing); err != nil {
+		t.Fatal(err)
+	}
+	read, err := Read(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(read, drawing) {
+		t.Errorf("got %+v, want %+v", read, drawing)
+	}
+}
+
+func TestEllipsePoints(t *testing.T) {
+	tests := []struct {
+		name    string
+		ellipse Ellipse
+		want    []Point
+	}{
+		{"full", Ellipse{MajorAxis: Point{2, 0}, Ratio: 0.5, EndParam: 2 * math.Pi}, []Point{{2, 0}, {0, 1}, {-2, 0}, {0, -1}, {2, 0}}},
+		{"rotated half", Ellipse{Center: Point{1, 1}, MajorAxis: Point{0, 2}, Ratio: 0.5, EndParam: math.Pi}, []Point{{1, 3}, {0.2929, 2.4142}, {0, 1}, {0.2929, -0.4142}, {1, -1}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			points := test.ellipse.Points(len(test.want) - 1)
+			for i, p := range points {
+				if math.Abs(p.X-test.want[i].X) > 1e-4 || math.Abs(p.Y-test.want[i].Y) > 1e-4 {
+					t.Errorf("point %d is %v, want %v", i, p, test.want[i])
+				}
+			}
+		})
+	}
+}
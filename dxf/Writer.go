@@ -0,0 +1,148 @@
+package dxf
+
+import (
+	"bufio"
+	"io"
+	"slices"
+	"strconv"
+)
+
+type groupWriter struct {
+	w *bufio.Writer
+}
+
+func (w *groupWriter) group(code int, value string) {
+	w.w.WriteString(strconv.Itoa(code))
+	w.w.WriteByte('\n')
+	w.w.WriteString(value)
+	w.w.WriteByte('\n')
+}
+
+func (w *groupWriter) float(code int, value float64) {
+	w.group(code, strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+func (w *groupWriter) int(code int, value int) {
+	w.group(code, strconv.Itoa(value))
+}
+
+func (w *groupWriter) point(code int, p Point) {
+	w.float(code, p.X)
+	w.float(code+10, p.Y)
+	w.float(code+20, 0)
+}
+
+// Write writes the drawing as an ASCII DXF file with a layer table entry for every layer used by its entities
+func Write(writer io.Writer, drawing *Drawing) error {
+	w := &groupWriter{bufio.NewWriter(writer)}
+
+	w.group(0, "SECTION")
+	w.group(2, "HEADER")
+	w.group(9, "$INSUNITS")
+	w.int(70, int(drawing.Units))
+	w.group(0, "ENDSEC")
+
+	layers := make([]string, 0)
+	for _, entity := range drawing.Entities {
+		if !slices.Contains(layers, entity.EntityLayer()) {
+			layers = append(layers, entity.EntityLayer())
+		}
+	}
+	w.group(0, "SECTION")
+	w.group(2, "TABLES")
+	w.group(0, "TABLE")
+	w.group(2, "LAYER")
+	w.int(70, len(layers))
+	for _, layer := range layers {
+		w.group(0, "LAYER")
+		w.group(2, layer)
+		w.int(70, 0)
+		w.int(62, 7)
+		w.group(6, "CONTINUOUS")
+	}
+	w.group(0, "ENDTAB")
+	w.group(0, "ENDSEC")
+
+	w.group(0, "SECTION")
+	w.group(2, "ENTITIES")
+	for _, entity := range drawing.Entities {
+		w.entity(entity)
+	}
+	w.group(0, "ENDSEC")
+	w.group(0, "EOF")
+
+	return w.w.Flush()
+}
+
+func (w *groupWriter) entity(entity Entity) {
+	switch e := entity.(type) {
+	case *Line:
+		w.group(0, "LINE")
+		w.group(8, e.Layer)
+		w.point(10, e.Start)
+		w.point(11, e.End)
+	case *Arc:
+		w.group(0, "ARC")
+		w.group(8, e.Layer)
+		w.point(10, e.Center)
+		w.float(40, e.Radius)
+		w.float(50, e.StartAngle)
+		w.float(51, e.EndAngle)
+	case *Circle:
+		w.group(0, "CIRCLE")
+		w.group(8, e.Layer)
+		w.point(10, e.Center)
+		w.float(40, e.Radius)
+	case *Polyline:
+		w.group(0, "LWPOLYLINE")
+		w.group(8, e.Layer)
+		w.int(90, len(e.Vertices))
+		flags := 0
+		if e.Closed {
+			flags = 1
+		}
+		w.int(70, flags)
+		for _, vertex := range e.Vertices {
+			w.float(10, vertex.X)
+			w.float(20, vertex.Y)
+			if vertex.Bulge != 0 {
+				w.float(42, vertex.Bulge)
+			}
+		}
+	case *Ellipse:
+		w.group(0, "ELLIPSE")
+		w.group(8, e.Layer)
+		w.point(10, e.Center)
+		w.point(11, e.MajorAxis)
+		w.float(40, e.Ratio)
+		w.float(41, e.StartParam)
+		w.float(42, e.EndParam)
+	case *Spline:
+		w.group(0, "SPLINE")
+		w.group(8, e.Layer)
+		flags := 0
+		if e.Closed {
+			flags |= 1
+		}
+		if len(e.Weights) > 0 {
+			flags |= 4
+		}
+		w.int(70, flags)
+		w.int(71, e.Degree)
+		w.int(72, len(e.Knots))
+		w.int(73, len(e.ControlPoints))
+		w.int(74, len(e.FitPoints))
+		for _, knot := range e.Knots {
+			w.float(40, knot)
+		}
+		for _, weight := range e.Weights {
+			w.float(41, weight)
+		}
+		for _, p := range e.ControlPoints {
+			w.point(10, p)
+		}
+		for _, p := range e.FitPoints {
+			w.point(11, p)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+// Package glyph lays out text with TrueType and OpenType fonts and returns the glyph outlines as closed polylines
+package glyph
+
+import (
+	"errors"
+	"math"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// curveTolerance is the maximum distance between a glyph curve and the line segments approximating it, relative to the text height
+const curveTolerance = 0.002
+
+// Contours lays out the text with the font data and returns each glyph contour as a closed polyline. The baseline of
+// the first line starts at the origin with Y increasing upwards, and height is the font size (em height).
+// Curves are approximated with line segments.
+func Contours(text string, fontData []byte, height float64) ([][][2]float64, error) {
+	if height <= 0 {
+		return nil, errors.New("text height must be positive")
+	}
+	f, err := sfnt.Parse(fontData)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load glyphs in font units and scale them to the requested height
+	var buf sfnt.Buffer
+	ppem := fixed.Int26_6(f.UnitsPerEm()) << 6
+	scale := height / float64(f.UnitsPerEm())
+	scaled := func(v fixed.Int26_6) float64 { return float64(v) / 64 * scale }
+	metrics, err := f.Metrics(&buf, ppem, font.HintingNone)
+	if err != nil {
+		return nil, err
+	}
+	lineHeight := scaled(metrics.Height)
+	tolerance := height * curveTolerance
+
+	contours := make([][][2]float64, 0)
+	x, y := 0.0, 0.0
+	previous := sfnt.GlyphIndex(0)
+	for _, r := range text {
+		if r == '\n' {
+			x, y = 0, y-lineHeight
+			previous = 0
+			continue
+		}
+		glyph, err := f.GlyphIndex(&buf, r)
+		if err != nil {
+			return nil, err
+		}
+		if previous != 0 {
+			if kern, err := f.Kern(&buf, previous, glyph, ppem, font.HintingNone); err == nil {
+				x += scaled(kern)
+			}
+		}
+		segments, err := f.LoadGlyph(&buf, glyph, ppem, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		// Glyph outlines have Y increasing downwards
+		point := func(p fixed.Point26_6) [2]float64 { return [2]float64{x + scaled(p.X), y - scaled(p.Y)} }
+		var contour [][2]float64
+		for _, segment := range segments {
+			switch segment.Op {
+			case sfnt.SegmentOpMoveTo:
+				contours = appendContour(contours, contour)
+				contour = [][2]float64{point(segment.Args[0])}
+			case sfnt.SegmentOpLineTo:
+				contour = append(contour, point(segment.Args[0]))
+			case sfnt.SegmentOpQuadTo:
+				contour = appendQuadratic(contour, point(segment.Args[0]), point(segment.Args[1]), tolerance)
+			case sfnt.SegmentOpCubeTo:
+				contour = AppendCubic(contour, point(segment.Args[0]), point(segment.Args[1]), point(segment.Args[2]), tolerance)
+			}
+		}
+		contours = appendContour(contours, contour)
+
+		advance, err := f.GlyphAdvance(&buf, glyph, ppem, font.HintingNone)
+		if err != nil {
+			return nil, err
+		}
+		x += scaled(advance)
+		previous = glyph
+	}
+
+	return contours, nil
+}
+
+// appendContour removes repeated points from a closed contour and appends it if it still encloses an area
+func appendContour(contours [][][2]float64, contour [][2]float64) [][][2]float64 {
+	cleaned := make([][2]float64, 0, len(contour))
+	for _, p := range contour {
+		if len(cleaned) > 0 && distance2D(cleaned[len(cleaned)-1], p) < 1e-9 {
+			continue
+		}
+		cleaned = append(cleaned, p)
+	}
+	for len(cleaned) > 1 && distance2D(cleaned[0], cleaned[len(cleaned)-1]) < 1e-9 {
+		cleaned = cleaned[:len(cleaned)-1]
+	}
+	if len(cleaned) < 3 {
+		return contours
+	}
+	return append(contours, cleaned)
+}
+
+// curveSegments returns how many line segments are needed to approximate a curve with the control polygon length within tolerance
+func curveSegments(controlLength float64, tolerance float64) int {
+	segments := int(math.Ceil(math.Sqrt(controlLength / tolerance)))
+	return max(1, min(segments, 64))
+}
+
+// appendQuadratic approximates a quadratic Bézier curve from the last point of the polyline with line segments
+func appendQuadratic(polyline [][2]float64, control, end [2]float64, tolerance float64) [][2]float64 {
+	start := polyline[len(polyline)-1]
+	n := curveSegments(distance2D(start, control)+distance2D(control, end), tolerance)
+	for i := 1; i <= n; i++ {
+		t := float64(i) / float64(n)
+		u := 1 - t
+		polyline = append(polyline, [2]float64{
+			u*u*start[0] + 2*u*t*control[0] + t*t*end[0],
+			u*u*start[1] + 2*u*t*control[1] + t*t*end[1],
+		})
+	}
+	return polyline
+}
+
+// AppendCubic approximates a cubic Bézier curve from the last point of the polyline with line segments
+func AppendCubic(polyline [][2]float64, control1, control2, end [2]float64, tolerance float64) [][2]float64 {
+	start := polyline[len(polyline)-1]
+	n := curveSegments(distance2D(start, control1)+distance2D(control1, control2)+distance2D(control2, end), tolerance)
+	for i := 1; i <= n; i++ {
+		t := float64(i) / float64(n)
+		u := 1 - t
+		polyline = append(polyline, [2]float64{
+			u*u*u*start[0] + 3*u*u*t*control1[0] + 3*u*t*t*control2[0] + t*t*t*end[0],
+			u*u*u*start[1] + 3*u*u*t*control1[1] + 3*u*t*t*control2[1] + t*t*t*end[1],
+		})
+	}
+	return polyline
+}
+
+func distance2D(a, b [2]float64) float64 {
+	return math.Hypot(a[0]-b[0], a[1]-b[1])
+}
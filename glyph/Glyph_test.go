@@ -0,0 +1,203 @@
+package glyph
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// extent returns the lowest and highest coordinates of the contours
+func extent(contours [][][2]float64) (low, high [2]float64) {
+	low, high = [2]float64{math.Inf(1), math.Inf(1)}, [2]float64{math.Inf(-1), math.Inf(-1)}
+	for _, contour := range contours {
+		for _, p := range contour {
+			low = [2]float64{min(low[0], p[0]), min(low[1], p[1])}
+			high = [2]float64{max(high[0], p[0]), max(high[1], p[1])}
+		}
+	}
+	return low, high
+}
+
+func TestContours(t *testing.T) {
+	tests := []struct {
+		text     string
+		contours int
+	}{
+		{"", 0},
+		{" ", 0},
+		{"l", 1},
+		{"o", 2},
+		{"i", 2},
+		{"B", 3},
+		{"lo", 3},
+		{"l\nl", 2},
+	}
+	for _, test := range tests {
+		t.Run(test.text, func(t *testing.T) {
+			contours, err := Contours(test.text, goregular.TTF, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(contours) != test.contours {
+				t.Fatalf("got %d contours, want %d", len(contours), test.contours)
+			}
+			for i, contour := range contours {
+				if len(contour) < 3 {
+					t.Errorf("contour %d has %d points", i, len(contour))
+				}
+				if distance2D(contour[0], contour[len(contour)-1]) < 1e-9 {
+					t.Errorf("contour %d repeats its first point", i)
+				}
+			}
+		})
+	}
+}
+
+func TestContoursLayout(t *testing.T) {
+	single, err := Contours("l", goregular.TTF, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	low, high := extent(single)
+	// The letter starts just right of the origin, sits on the baseline and rises by about the ascender height
+	if low[1] < -0.5 || low[1] > 0.01 || high[1] < 6 || high[1] > 10 || low[0] < 0 || low[0] > 2 {
+		t.Errorf("l spans %v to %v", low, high)
+	}
+
+	doubled, err := Contours("l", goregular.TTF, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doubledLow, doubledHigh := extent(doubled)
+	if math.Abs(doubledHigh[1]-2*high[1]) > 1e-6 || math.Abs(doubledLow[0]-2*low[0]) > 1e-6 {
+		t.Errorf("doubling the height spans %v to %v, want %v to %v", doubledLow, doubledHigh, low, high)
+	}
+
+	lines, err := Contours("l\nl", goregular.TTF, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if firstLow, _ := extent(lines[:1]); math.Abs(firstLow[1]-low[1]) > 1e-9 {
+		t.Errorf("first line starts at %v, want %v", firstLow[1], low[1])
+	}
+	if _, secondHigh := extent(lines[1:]); secondHigh[1] >= 0 {
+		t.Errorf("second line reaches %v, want it below the baseline", secondHigh[1])
+	}
+
+	pair, err := Contours("ll", goregular.TTF, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if secondLow, _ := extent(pair[1:]); secondLow[0] <= high[0] {
+		t.Errorf("second letter starts at %v, inside the first ending at %v", secondLow[0], high[0])
+	}
+}
+
+func TestContoursErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		font   []byte
+		height float64
+	}{
+		{"zero height", goregular.TTF, 0},
+		{"negative height", goregular.TTF, -1},
+		{"not a font", []byte("not a font"), 10},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := Contours("a", test.font, test.height); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestAppendContour(t *testing.T) {
+	tests := []struct {
+		name    string
+		contour [][2]float64
+		want    [][2]float64
+	}{
+		{"triangle", [][2]float64{{0, 0}, {1, 0}, {0, 1}}, [][2]float64{{0, 0}, {1, 0}, {0, 1}}},
+		{"closing point removed", [][2]float64{{0, 0}, {1, 0}, {0, 1}, {0, 0}}, [][2]float64{{0, 0}, {1, 0}, {0, 1}}},
+		{"repeated points removed", [][2]float64{{0, 0}, {1, 0}, {1, 0}, {0, 1}}, [][2]float64{{0, 0}, {1, 0}, {0, 1}}},
+		{"degenerate", [][2]float64{{0, 0}, {1, 0}, {0, 0}}, nil},
+		{"empty", nil, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			contours := appendContour(nil, test.contour)
+			if test.want == nil {
+				if len(contours) != 0 {
+					t.Errorf("got %v, want no contour", contours)
+				}
+				return
+			}
+			if len(contours) != 1 || len(contours[0]) != len(test.want) {
+				t.Fatalf("got %v, want %v", contours, test.want)
+			}
+			for i, p := range contours[0] {
+				if p != test.want[i] {
+					t.Errorf("got %v, want %v", contours[0], test.want)
+				}
+			}
+		})
+	}
+}
+
+func TestCurves(t *testing.T) {
+	const tolerance = 0.01
+	start, control1, control2, end := [2]float64{0, 0}, [2]float64{0, 1}, [2]float64{1, 1}, [2]float64{1, 0}
+	tests := []struct {
+		name  string
+		curve func() [][2]float64
+		exact func(t float64) [2]float64
+	}{
+		{
+			name:  "quadratic",
+			curve: func() [][2]float64 { return appendQuadratic([][2]float64{start}, control1, end, tolerance) },
+			exact: func(t float64) [2]float64 { return [2]float64{t * t, 2 * (1 - t) * t} },
+		},
+		{
+			name:  "cubic",
+			curve: func() [][2]float64 { return AppendCubic([][2]float64{start}, control1, control2, end, tolerance) },
+			exact: func(t float64) [2]float64 {
+				return [2]float64{3*(1-t)*t*t + t*t*t, 3*(1-t)*(1-t)*t + 3*(1-t)*t*t}
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			polyline := test.curve()
+			if polyline[0] != start || distance2D(polyline[len(polyline)-1], end) > 1e-12 {
+				t.Errorf("polyline runs from %v to %v, want %v to %v", polyline[0], polyline[len(polyline)-1], start, end)
+			}
+			// Each segment's midpoint stays within the tolerance of the curve between the segment's ends
+			n := len(polyline) - 1
+			for i := range n {
+				mid := [2]float64{(polyline[i][0] + polyline[i+1][0]) / 2, (polyline[i][1] + polyline[i+1][1]) / 2}
+				if d := distance2D(mid, test.exact((float64(i)+0.5)/float64(n))); d > tolerance {
+					t.Errorf("segment %d is %v from the curve", i, d)
+				}
+			}
+		})
+	}
+}
+
+func TestCurveSegments(t *testing.T) {
+	tests := []struct {
+		length    float64
+		tolerance float64
+		want      int
+	}{
+		{0, 0.1, 1},
+		{1, 0.01, 10},
+		{1, 1e-9, 64},
+	}
+	for _, test := range tests {
+		if got := curveSegments(test.length, test.tolerance); got != test.want {
+			t.Errorf("curveSegments(%v, %v) is %d, want %d", test.length, test.tolerance, got, test.want)
+		}
+	}
+}
@@ -8,6 +8,7 @@ require (
 	github.com/marcuswu/dlineate v0.2.2
 	github.com/marcuswu/gooccwrapper v0.1.6
 	github.com/rs/zerolog v1.34.0
+	golang.org/x/image v0.25.0
 )
 
 require (
@@ -15,6 +16,7 @@ require (
 	github.com/mattn/go-colorable v0.1.14 // indirect
 	github.com/mattn/go-isatty v0.0.20 // indirect
 	golang.org/x/sys v0.40.0 // indirect
+	golang.org/x/text v0.23.0 // indirect
 	golang.org/x/tools v0.40.0 // indirect
 	gonum.org/v1/gonum v0.16.0 // indirect
 )
@@ -0,0 +1,206 @@
+package mesh
+
+import (
+	"errors"
+	"math"
+)
+
+// orientedSamples is the number of points an arc is sampled at to find the axes of an oriented bounding box
+const orientedSamples = 16
+
+// ErrEmptyBounds is returned for the box of a [Bounds] holding no geometry
+var ErrEmptyBounds = errors.New("there is no geometry to bound")
+
+// BoundingBox is a box enclosing a shape, face or edge. Axes are the unit directions of the box's edges, which are the
+// global X, Y and Z axes for an axis-aligned box. Min and Max are the lowest and highest coordinates along each axis.
+type BoundingBox struct {
+	Min  Vector
+	Max  Vector
+	Axes [3]Vector
+}
+
+// Size returns the length of the box along each of its axes
+func (b BoundingBox) Size() Vector {
+	return b.Max.Sub(b.Min)
+}
+
+// Center returns the center of the box in global coordinates
+func (b BoundingBox) Center() Vector {
+	middle := b.Min.Add(b.Max).Scale(0.5)
+	return b.Axes[0].Scale(middle.X).Add(b.Axes[1].Scale(middle.Y)).Add(b.Axes[2].Scale(middle.Z))
+}
+
+// Volume returns the volume of the box
+func (b BoundingBox) Volume() float64 {
+	size := b.Size()
+	return size.X * size.Y * size.Z
+}
+
+// Arc is a circular arc running from Center + Radius*U towards Center + Radius*V by Sweep radians. U and V are
+// perpendicular unit vectors.
+type Arc struct {
+	Center Vector
+	U      Vector
+	V      Vector
+	Radius float64
+	Sweep  float64
+}
+
+// At returns the point of the arc the angle in radians from its start
+func (a Arc) At(angle float64) Vector {
+	return a.Center.Add(a.U.Scale(a.Radius * math.Cos(angle))).Add(a.V.Scale(a.Radius * math.Sin(angle)))
+}
+
+// Bounds collects the geometry a [BoundingBox] encloses: points, such as the vertices of a triangulation, and arcs,
+// which are enclosed exactly
+type Bounds struct {
+	points []Vector
+	arcs   []Arc
+}
+
+// AddPoint adds a point to enclose
+func (b *Bounds) AddPoint(p Vector) {
+	b.points = append(b.points, p)
+}
+
+// AddMesh adds the vertices of a mesh to enclose
+func (b *Bounds) AddMesh(m *Mesh) {
+	for _, triangle := range m.Triangles {
+		b.points = append(b.points, triangle[:]...)
+	}
+}
+
+// AddArc adds a circular arc to enclose
+func (b *Bounds) AddArc(arc Arc) {
+	b.arcs = append(b.arcs, arc)
+}
+
+// extent returns the lowest and highest coordinates of the geometry along a unit direction
+func (b *Bounds) extent(direction Vector) (float64, float64) {
+	low, high := math.Inf(1), math.Inf(-1)
+	include := func(p Vector) {
+		coordinate := p.Dot(direction)
+		low, high = min(low, coordinate), max(high, coordinate)
+	}
+	for _, p := range b.points {
+		include(p)
+	}
+	for _, arc := range b.arcs {
+		include(arc.At(0))
+		include(arc.At(arc.Sweep))
+		// The arc is furthest along the direction where its tangent is perpendicular to it
+		turning := math.Atan2(arc.V.Dot(direction), arc.U.Dot(direction))
+		for _, angle := range []float64{turning, turning + math.Pi} {
+			angle = math.Mod(angle+2*math.Pi, 2*math.Pi)
+			if angle <= arc.Sweep {
+				include(arc.At(angle))
+			}
+		}
+	}
+	return low, high
+}
+
+func (b *Bounds) box(axes [3]Vector) BoundingBox {
+	var low, high [3]float64
+	for i, axis := range axes {
+		low[i], high[i] = b.extent(axis)
+	}
+	return BoundingBox{Min: Vector{low[0], low[1], low[2]}, Max: Vector{high[0], high[1], high[2]}, Axes: axes}
+}
+
+// AxisAligned returns the smallest box with edges along the global axes enclosing the geometry
+func (b *Bounds) AxisAligned() (BoundingBox, error) {
+	if len(b.points) == 0 && len(b.arcs) == 0 {
+		return BoundingBox{}, ErrEmptyBounds
+	}
+	return b.box([3]Vector{{X: 1}, {Y: 1}, {Z: 1}}), nil
+}
+
+// Oriented returns a box enclosing the geometry aligned with its principal axes, or the axis-aligned box when that is smaller.
+// The principal axes give a close fitting box for most parts but not always the smallest possible one.
+func (b *Bounds) Oriented() (BoundingBox, error) {
+	aligned, err := b.AxisAligned()
+	if err != nil {
+		return BoundingBox{}, err
+	}
+	// Triangulations repeat shared vertices, which would weigh corners by the number of triangles meeting at them
+	samples := make([]Vector, 0, len(b.points)+len(b.arcs)*orientedSamples)
+	seen := make(map[Vector]bool, len(b.points))
+	for _, p := range b.points {
+		if !seen[p] {
+			seen[p] = true
+			samples = append(samples, p)
+		}
+	}
+	for _, arc := range b.arcs {
+		for i := range orientedSamples + 1 {
+			samples = append(samples, arc.At(arc.Sweep*float64(i)/orientedSamples))
+		}
+	}
+
+	mean := Vector{}
+	for _, p := range samples {
+		mean = mean.Add(p)
+	}
+	mean = mean.Scale(1 / float64(len(samples)))
+	var covariance [3][3]float64
+	for _, p := range samples {
+		d := p.Sub(mean)
+		components := [3]float64{d.X, d.Y, d.Z}
+		for i := range components {
+			for j := range components {
+				covariance[i][j] += components[i] * components[j]
+			}
+		}
+	}
+
+	axes := principalAxes(covariance)
+	// Keep the axes right handed
+	axes[2] = axes[0].Cross(axes[1]).Normalized()
+	oriented := b.box(axes)
+	if aligned.Volume() <= oriented.Volume() {
+		return aligned, nil
+	}
+	return oriented, nil
+}
+
+// principalAxes returns the eigenvectors of a symmetric 3x3 matrix found with the Jacobi eigenvalue method
+func principalAxes(m [3][3]float64) [3]Vector {
+	vectors := [3][3]float64{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+	for sweep := 0; sweep < 50; sweep++ {
+		off := m[0][1]*m[0][1] + m[0][2]*m[0][2] + m[1][2]*m[1][2]
+		if off < 1e-24*(m[0][0]*m[0][0]+m[1][1]*m[1][1]+m[2][2]*m[2][2]) || off == 0 {
+			break
+		}
+		for p := 0; p < 2; p++ {
+			for q := p + 1; q < 3; q++ {
+				if m[p][q] == 0 {
+					continue
+				}
+				// Rotate in the p, q plane to zero m[p][q]
+				theta := (m[q][q] - m[p][p]) / (2 * m[p][q])
+				t := math.Copysign(1, theta) / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+				for k := 0; k < 3; k++ {
+					mkp, mkq := m[k][p], m[k][q]
+					m[k][p], m[k][q] = c*mkp-s*mkq, s*mkp+c*mkq
+				}
+				for k := 0; k < 3; k++ {
+					mpk, mqk := m[p][k], m[q][k]
+					m[p][k], m[q][k] = c*mpk-s*mqk, s*mpk+c*mqk
+				}
+				for k := 0; k < 3; k++ {
+					vkp, vkq := vectors[k][p], vectors[k][q]
+					vectors[k][p], vectors[k][q] = c*vkp-s*vkq, s*vkp+c*vkq
+				}
+			}
+		}
+	}
+
+	var axes [3]Vector
+	for i := range axes {
+		axes[i] = Vector{vectors[0][i], vectors[1][i], vectors[2][i]}.Normalized()
+	}
+	return axes
+}
@@ -0,0 +1,152 @@
+package mesh
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func near(a, b Vector) bool {
+	return a.Sub(b).Length() < 1e-9
+}
+
+func TestAxisAligned(t *testing.T) {
+	quarter := Arc{Center: Vector{1, 1, 0}, U: Vector{X: 1}, V: Vector{Y: 1}, Radius: 2, Sweep: math.Pi / 2}
+	tests := []struct {
+		name   string
+		points []Vector
+		arcs   []Arc
+		min    Vector
+		max    Vector
+	}{
+		{"points", []Vector{{1, 2, 3}, {-1, 5, 0}, {0, 0, 4}}, nil, Vector{-1, 0, 0}, Vector{1, 5, 4}},
+		{"quarter arc", nil, []Arc{quarter}, Vector{1, 1, 0}, Vector{3, 3, 0}},
+		{"full circle", nil, []Arc{{Center: Vector{}, U: Vector{Y: 1}, V: Vector{Z: 1}, Radius: 1, Sweep: 2 * math.Pi}}, Vector{0, -1, -1}, Vector{0, 1, 1}},
+		// An arc from +X through -X bulges to +Y, so its extent is set by its ends along X and its middle along Y
+		{"half arc", nil, []Arc{{Center: Vector{}, U: Vector{X: 1}, V: Vector{Y: 1}, Radius: 1, Sweep: math.Pi}}, Vector{-1, 0, 0}, Vector{1, 1, 0}},
+		{"points and arcs", []Vector{{0, 0, -1}}, []Arc{quarter}, Vector{0, 0, -1}, Vector{3, 3, 0}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bounds := &Bounds{}
+			for _, p := range test.points {
+				bounds.AddPoint(p)
+			}
+			for _, arc := range test.arcs {
+				bounds.AddArc(arc)
+			}
+			box, err := bounds.AxisAligned()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !near(box.Min, test.min) || !near(box.Max, test.max) {
+				t.Errorf("box is %v to %v, want %v to %v", box.Min, box.Max, test.min, test.max)
+			}
+		})
+	}
+}
+
+func TestBoundingBox(t *testing.T) {
+	bounds := &Bounds{}
+	bounds.AddMesh(box(Vector{1, 2, 3}, Vector{2, 1, 3}))
+	aligned, err := bounds.AxisAligned()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size := aligned.Size(); !near(size, Vector{2, 1, 3}) {
+		t.Errorf("size is %v, want %v", size, Vector{2, 1, 3})
+	}
+	if center := aligned.Center(); !near(center, Vector{2, 2.5, 4.5}) {
+		t.Errorf("center is %v, want %v", center, Vector{2, 2.5, 4.5})
+	}
+	if volume := aligned.Volume(); math.Abs(volume-6) > 1e-9 {
+		t.Errorf("volume is %v, want 6", volume)
+	}
+}
+
+func TestEmptyBounds(t *testing.T) {
+	bounds := &Bounds{}
+	if _, err := bounds.AxisAligned(); !errors.Is(err, ErrEmptyBounds) {
+		t.Errorf("got error %v, want %v", err, ErrEmptyBounds)
+	}
+	if _, err := bounds.Oriented(); !errors.Is(err, ErrEmptyBounds) {
+		t.Errorf("got error %v, want %v", err, ErrEmptyBounds)
+	}
+}
+
+func TestOriented(t *testing.T) {
+	// A 4 x 1 x 0.5 box turned 30 degrees about Z
+	angle := math.Pi / 6
+	turn := func(v Vector) Vector {
+		return Vector{v.X*math.Cos(angle) - v.Y*math.Sin(angle), v.X*math.Sin(angle) + v.Y*math.Cos(angle), v.Z}
+	}
+	turned := box(Vector{-2, -0.5, -0.25}, Vector{4, 1, 0.5})
+	for i, triangle := range turned.Triangles {
+		for j := range triangle {
+			turned.Triangles[i][j] = turn(triangle[j])
+		}
+	}
+
+	bounds := &Bounds{}
+	bounds.AddMesh(turned)
+	oriented, err := bounds.Oriented()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if volume := oriented.Volume(); math.Abs(volume-2) > 1e-6 {
+		t.Errorf("oriented volume is %v, want 2", volume)
+	}
+	if center := oriented.Center(); !near(center, Vector{}) {
+		t.Errorf("center is %v, want the origin", center)
+	}
+	long := false
+	for _, axis := range oriented.Axes {
+		long = long || math.Abs(math.Abs(axis.Dot(turn(Vector{X: 1})))-1) < 1e-6
+	}
+	if !long {
+		t.Errorf("no axis of %v is along the long side of the box", oriented.Axes)
+	}
+	if handed := oriented.Axes[0].Cross(oriented.Axes[1]).Dot(oriented.Axes[2]); math.Abs(handed-1) > 1e-9 {
+		t.Errorf("axes %v are not right handed", oriented.Axes)
+	}
+
+	// An axis-aligned box is returned unchanged
+	bounds = &Bounds{}
+	bounds.AddMesh(box(Vector{}, Vector{3, 2, 1}))
+	oriented, err = bounds.Oriented()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if volume := oriented.Volume(); math.Abs(volume-6) > 1e-9 {
+		t.Errorf("axis-aligned volume is %v, want 6", volume)
+	}
+}
+
+func TestPrincipalAxes(t *testing.T) {
+	tests := []struct {
+		name   string
+		matrix [3][3]float64
+		// want are the expected axes in any order and sign
+		want [3]Vector
+	}{
+		{"diagonal", [3][3]float64{{3, 0, 0}, {0, 2, 0}, {0, 0, 1}}, [3]Vector{{X: 1}, {Y: 1}, {Z: 1}}},
+		{"rotated about Z", [3][3]float64{{2, 1, 0}, {1, 2, 0}, {0, 0, 5}}, [3]Vector{
+			{math.Sqrt2 / 2, math.Sqrt2 / 2, 0}, {math.Sqrt2 / 2, -math.Sqrt2 / 2, 0}, {Z: 1},
+		}},
+		{"zero", [3][3]float64{}, [3]Vector{{X: 1}, {Y: 1}, {Z: 1}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			axes := principalAxes(test.matrix)
+			for _, want := range test.want {
+				found := false
+				for _, axis := range axes {
+					found = found || math.Abs(math.Abs(axis.Dot(want))-1) < 1e-9
+				}
+				if !found {
+					t.Errorf("axes %v do not include %v", axes, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,302 @@
+// Package mesh holds triangle meshes, such as the triangulation of a shape read back from an STL file
+package mesh
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// Vector is a point or direction in 3D space
+type Vector struct {
+	X float64
+	Y float64
+	Z float64
+}
+
+func (v Vector) Add(o Vector) Vector {
+	return Vector{v.X + o.X, v.Y + o.Y, v.Z + o.Z}
+}
+
+func (v Vector) Sub(o Vector) Vector {
+	return Vector{v.X - o.X, v.Y - o.Y, v.Z - o.Z}
+}
+
+func (v Vector) Scale(factor float64) Vector {
+	return Vector{v.X * factor, v.Y * factor, v.Z * factor}
+}
+
+func (v Vector) Dot(o Vector) float64 {
+	return v.X*o.X + v.Y*o.Y + v.Z*o.Z
+}
+
+func (v Vector) Cross(o Vector) Vector {
+	return Vector{v.Y*o.Z - v.Z*o.Y, v.Z*o.X - v.X*o.Z, v.X*o.Y - v.Y*o.X}
+}
+
+func (v Vector) Length() float64 {
+	return math.Sqrt(v.Dot(v))
+}
+
+// Normalized returns the vector scaled to length 1, or the zero vector unchanged
+func (v Vector) Normalized() Vector {
+	length := v.Length()
+	if length == 0 {
+		return v
+	}
+	return v.Scale(1 / length)
+}
+
+// Triangle is a triangle of a mesh. Its vertices run counterclockwise seen from outside the meshed shape.
+type Triangle [3]Vector
+
+// Normal returns the outward unit normal of the triangle, or the zero vector for a degenerate triangle
+func (t Triangle) Normal() Vector {
+	return t[1].Sub(t[0]).Cross(t[2].Sub(t[0])).Normalized()
+}
+
+func (t Triangle) Area() float64 {
+	return t[1].Sub(t[0]).Cross(t[2].Sub(t[0])).Length() / 2
+}
+
+func (t Triangle) Centroid() Vector {
+	return t[0].Add(t[1]).Add(t[2]).Scale(1. / 3.)
+}
+
+// ClosestPoint returns the point of the triangle closest to p
+func (t Triangle) ClosestPoint(p Vector) Vector {
+	a, b, c := t[0], t[1], t[2]
+	ab, ac, ap := b.Sub(a), c.Sub(a), p.Sub(a)
+
+	// Find the Voronoi region of the triangle containing p (Ericson, Real-Time Collision Detection 5.1.5)
+	d1, d2 := ab.Dot(ap), ac.Dot(ap)
+	if d1 <= 0 && d2 <= 0 {
+		return a
+	}
+	bp := p.Sub(b)
+	d3, d4 := ab.Dot(bp), ac.Dot(bp)
+	if d3 >= 0 && d4 <= d3 {
+		return b
+	}
+	vc := d1*d4 - d3*d2
+	if vc <= 0 && d1 >= 0 && d3 <= 0 {
+		return a.Add(ab.Scale(d1 / (d1 - d3)))
+	}
+	cp := p.Sub(c)
+	d5, d6 := ab.Dot(cp), ac.Dot(cp)
+	if d6 >= 0 && d5 <= d6 {
+		return c
+	}
+	vb := d5*d2 - d1*d6
+	if vb <= 0 && d2 >= 0 && d6 <= 0 {
+		return a.Add(ac.Scale(d2 / (d2 - d6)))
+	}
+	va := d3*d6 - d5*d4
+	if va <= 0 && d4-d3 >= 0 && d5-d6 >= 0 {
+		return b.Add(c.Sub(b).Scale((d4 - d3) / ((d4 - d3) + (d5 - d6))))
+	}
+	denominator := 1 / (va + vb + vc)
+	return a.Add(ab.Scale(vb * denominator)).Add(ac.Scale(vc * denominator))
+}
+
+// Mesh is a list of triangles
+type Mesh struct {
+	Triangles []Triangle
+}
+
+// Closest returns the index of the triangle closest to p, or -1 for an empty mesh
+func (m *Mesh) Closest(p Vector) int {
+	closest := -1
+	closestDistance := math.Inf(1)
+	for i, triangle := range m.Triangles {
+		if distance := triangle.ClosestPoint(p).Sub(p).Length(); distance < closestDistance {
+			closest, closestDistance = i, distance
+		}
+	}
+	return closest
+}
+
+// Area returns the total area of the triangles
+func (m *Mesh) Area() float64 {
+	area := 0.0
+	for _, triangle := range m.Triangles {
+		area += triangle.Area()
+	}
+	return area
+}
+
+// MassProperties are the properties of the solid enclosed by a mesh at unit density
+type MassProperties struct {
+	Volume float64
+	// Center is the center of mass
+	Center Vector
+	// Inertia is the inertia tensor about the center of mass
+	Inertia [3][3]float64
+}
+
+// MassProperties integrates over the solid enclosed by the mesh, which must be closed with outward facing triangles.
+// It uses the divergence theorem as described by Eberly in "Polyhedral Mass Properties (Revisited)".
+func (m *Mesh) MassProperties() MassProperties {
+	subexpressions := func(w0, w1, w2 float64) (f1, f2, f3, g0, g1, g2 float64) {
+		temp0 := w0 + w1
+		f1 = temp0 + w2
+		temp1 := w0 * w0
+		temp2 := temp1 + w1*temp0
+		f2 = temp2 + w2*f1
+		f3 = w0*temp1 + w1*temp2 + w2*f2
+		g0 = f2 + w0*(f1+w0)
+		g1 = f2 + w1*(f1+w1)
+		g2 = f2 + w2*(f1+w2)
+		return
+	}
+
+	// The integrals of 1, x, y, z, x², y², z², xy, yz and zx over the solid
+	var integral [10]float64
+	for _, t := range m.Triangles {
+		d := t[1].Sub(t[0]).Cross(t[2].Sub(t[0]))
+		f1x, f2x, f3x, g0x, g1x, g2x := subexpressions(t[0].X, t[1].X, t[2].X)
+		_, f2y, f3y, g0y, g1y, g2y := subexpressions(t[0].Y, t[1].Y, t[2].Y)
+		_, f2z, f3z, g0z, g1z, g2z := subexpressions(t[0].Z, t[1].Z, t[2].Z)
+		integral[0] += d.X * f1x
+		integral[1] += d.X * f2x
+		integral[2] += d.Y * f2y
+		integral[3] += d.Z * f2z
+		integral[4] += d.X * f3x
+		integral[5] += d.Y * f3y
+		integral[6] += d.Z * f3z
+		integral[7] += d.X * (t[0].Y*g0x + t[1].Y*g1x + t[2].Y*g2x)
+		integral[8] += d.Y * (t[0].Z*g0y + t[1].Z*g1y + t[2].Z*g2y)
+		integral[9] += d.Z * (t[0].X*g0z + t[1].X*g1z + t[2].X*g2z)
+	}
+	for i, factor := range [10]float64{6, 24, 24, 24, 60, 60, 60, 120, 120, 120} {
+		integral[i] /= factor
+	}
+
+	volume := integral[0]
+	if volume == 0 {
+		return MassProperties{}
+	}
+	c := Vector{integral[1] / volume, integral[2] / volume, integral[3] / volume}
+	xx := integral[5] + integral[6] - volume*(c.Y*c.Y+c.Z*c.Z)
+	yy := integral[4] + integral[6] - volume*(c.Z*c.Z+c.X*c.X)
+	zz := integral[4] + integral[5] - volume*(c.X*c.X+c.Y*c.Y)
+	xy := -(integral[7] - volume*c.X*c.Y)
+	yz := -(integral[8] - volume*c.Y*c.Z)
+	zx := -(integral[9] - volume*c.Z*c.X)
+	return MassProperties{
+		Volume:  volume,
+		Center:  c,
+		Inertia: [3][3]float64{{xx, xy, zx}, {xy, yy, yz}, {zx, yz, zz}},
+	}
+}
+
+// Intersection is a point where two triangles of a mesh cross
+type Intersection struct {
+	First  int
+	Second int
+	Point  Vector
+}
+
+// Intersections returns the pairs of triangles which cross each other without sharing a vertex, which a closed mesh of a
+// valid solid does not have. Vertices closer than tolerance are considered shared.
+func (m *Mesh) Intersections(tolerance float64) []Intersection {
+	return m.IntersectionsWhere(tolerance, nil)
+}
+
+// IntersectionsWhere is [Mesh.Intersections] only testing the pairs of triangle indexes test accepts, or all pairs for
+// a nil test
+func (m *Mesh) IntersectionsWhere(tolerance float64, test func(first, second int) bool) []Intersection {
+	type extent struct {
+		index    int
+		low      Vector
+		high     Vector
+		triangle Triangle
+	}
+	extents := make([]extent, len(m.Triangles))
+	for i, t := range m.Triangles {
+		extents[i] = extent{i, t[0], t[0], t}
+		for _, v := range t[1:] {
+			extents[i].low = Vector{min(extents[i].low.X, v.X), min(extents[i].low.Y, v.Y), min(extents[i].low.Z, v.Z)}
+			extents[i].high = Vector{max(extents[i].high.X, v.X), max(extents[i].high.Y, v.Y), max(extents[i].high.Z, v.Z)}
+		}
+	}
+	slices.SortFunc(extents, func(a, b extent) int { return cmp.Compare(a.low.X, b.low.X) })
+
+	intersections := make([]Intersection, 0)
+	for i, a := range extents {
+		for _, b := range extents[i+1:] {
+			if b.low.X > a.high.X+tolerance {
+				break
+			}
+			if b.low.Y > a.high.Y+tolerance || a.low.Y > b.high.Y+tolerance ||
+				b.low.Z > a.high.Z+tolerance || a.low.Z > b.high.Z+tolerance {
+				continue
+			}
+			if test != nil && !test(min(a.index, b.index), max(a.index, b.index)) {
+				continue
+			}
+			if a.triangle.sharesVertex(b.triangle, tolerance) {
+				continue
+			}
+			if point, ok := a.triangle.crossing(b.triangle); ok {
+				first, second := min(a.index, b.index), max(a.index, b.index)
+				intersections = append(intersections, Intersection{first, second, point})
+			}
+		}
+	}
+	slices.SortFunc(intersections, func(a, b Intersection) int {
+		return cmp.Or(cmp.Compare(a.First, b.First), cmp.Compare(a.Second, b.Second))
+	})
+	return intersections
+}
+
+func (t Triangle) sharesVertex(o Triangle, tolerance float64) bool {
+	for _, a := range t {
+		for _, b := range o {
+			if a.Sub(b).Length() <= tolerance {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// crossing returns a point where an edge of one triangle passes through the interior of the other
+func (t Triangle) crossing(o Triangle) (Vector, bool) {
+	for _, pair := range [2][2]Triangle{{t, o}, {o, t}} {
+		edges, surface := pair[0], pair[1]
+		for i := range edges {
+			if point, ok := surface.segmentCrossing(edges[i], edges[(i+1)%3]); ok {
+				return point, true
+			}
+		}
+	}
+	return Vector{}, false
+}
+
+// segmentCrossing returns where the segment from p to q passes through the interior of the triangle (Möller–Trumbore)
+func (t Triangle) segmentCrossing(p, q Vector) (Vector, bool) {
+	const epsilon = 1e-9
+	direction := q.Sub(p)
+	e1, e2 := t[1].Sub(t[0]), t[2].Sub(t[0])
+	h := direction.Cross(e2)
+	a := e1.Dot(h)
+	if math.Abs(a) < epsilon*direction.Length()*e1.Length()*e2.Length() {
+		return Vector{}, false
+	}
+	s := p.Sub(t[0])
+	u := s.Dot(h) / a
+	if u <= epsilon || u >= 1-epsilon {
+		return Vector{}, false
+	}
+	r := s.Cross(e1)
+	v := direction.Dot(r) / a
+	if v <= epsilon || u+v >= 1-epsilon {
+		return Vector{}, false
+	}
+	along := e2.Dot(r) / a
+	if along <= epsilon || along >= 1-epsilon {
+		return Vector{}, false
+	}
+	return p.Add(direction.Scale(along)), true
+}
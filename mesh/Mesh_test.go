@@ -0,0 +1,150 @@
+package mesh
+
+import (
+	"math"
+	"testing"
+)
+
+// box returns a closed mesh of an axis aligned box with outward facing triangles
+func box(low Vector, size Vector) *Mesh {
+	corner := func(x, y, z float64) Vector {
+		return Vector{low.X + x*size.X, low.Y + y*size.Y, low.Z + z*size.Z}
+	}
+	quads := [][4]Vector{
+		{corner(0, 0, 0), corner(0, 0, 1), corner(0, 1, 1), corner(0, 1, 0)},
+		{corner(1, 0, 0), corner(1, 1, 0), corner(1, 1, 1), corner(1, 0, 1)},
+		{corner(0, 0, 0), corner(1, 0, 0), corner(1, 0, 1), corner(0, 0, 1)},
+		{corner(0, 1, 0), corner(0, 1, 1), corner(1, 1, 1), corner(1, 1, 0)},
+		{corner(0, 0, 0), corner(0, 1, 0), corner(1, 1, 0), corner(1, 0, 0)},
+		{corner(0, 0, 1), corner(1, 0, 1), corner(1, 1, 1), corner(0, 1, 1)},
+	}
+	mesh := &Mesh{}
+	for _, q := range quads {
+		mesh.Triangles = append(mesh.Triangles, Triangle{q[0], q[1], q[2]}, Triangle{q[0], q[2], q[3]})
+	}
+	return mesh
+}
+
+// tetrahedron returns a closed mesh of the tetrahedron with a corner at the origin and the others on the unit axes
+func tetrahedron() *Mesh {
+	v := []Vector{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+	return &Mesh{Triangles: []Triangle{{v[0], v[2], v[1]}, {v[0], v[1], v[3]}, {v[0], v[3], v[2]}, {v[1], v[2], v[3]}}}
+}
+
+func TestArea(t *testing.T) {
+	tests := []struct {
+		name string
+		mesh *Mesh
+		want float64
+	}{
+		{"empty", &Mesh{}, 0},
+		{"unit cube", box(Vector{}, Vector{1, 1, 1}), 6},
+		{"box", box(Vector{1, 2, 3}, Vector{2, 1, 3}), 22},
+		{"tetrahedron", tetrahedron(), 1.5 + math.Sqrt(3)/2},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if area := test.mesh.Area(); math.Abs(area-test.want) > 1e-9 {
+				t.Errorf("area is %v, want %v", area, test.want)
+			}
+		})
+	}
+}
+
+func TestMassProperties(t *testing.T) {
+	tests := []struct {
+		name string
+		mesh *Mesh
+		want MassProperties
+	}{
+		{"empty", &Mesh{}, MassProperties{}},
+		{
+			name: "unit cube",
+			mesh: box(Vector{}, Vector{1, 1, 1}),
+			want: MassProperties{1, Vector{0.5, 0.5, 0.5}, [3][3]float64{{1.0 / 6, 0, 0}, {0, 1.0 / 6, 0}, {0, 0, 1.0 / 6}}},
+		},
+		{
+			// A box of mass m and sides a, b and c has Ixx = m(b² + c²)/12
+			name: "offset box",
+			mesh: box(Vector{1, 2, 3}, Vector{2, 1, 3}),
+			want: MassProperties{6, Vector{2, 2.5, 4.5}, [3][3]float64{{5, 0, 0}, {0, 6.5, 0}, {0, 0, 2.5}}},
+		},
+		{
+			name: "tetrahedron",
+			mesh: tetrahedron(),
+			want: MassProperties{1.0 / 6, Vector{0.25, 0.25, 0.25}, [3][3]float64{
+				{1.0 / 80, 1.0 / 480, 1.0 / 480},
+				{1.0 / 480, 1.0 / 80, 1.0 / 480},
+				{1.0 / 480, 1.0 / 480, 1.0 / 80},
+			}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			props := test.mesh.MassProperties()
+			if math.Abs(props.Volume-test.want.Volume) > 1e-9 {
+				t.Errorf("volume is %v, want %v", props.Volume, test.want.Volume)
+			}
+			if props.Center.Sub(test.want.Center).Length() > 1e-9 {
+				t.Errorf("center is %v, want %v", props.Center, test.want.Center)
+			}
+			for i := range props.Inertia {
+				for j := range props.Inertia[i] {
+					if math.Abs(props.Inertia[i][j]-test.want.Inertia[i][j]) > 1e-9 {
+						t.Errorf("inertia is %v, want %v", props.Inertia, test.want.Inertia)
+						return
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestIntersections(t *testing.T) {
+	flat := Triangle{{0, 0, 0}, {2, 0, 0}, {0, 2, 0}}
+	tests := []struct {
+		name string
+		mesh *Mesh
+		want []Intersection
+	}{
+		{"closed box", box(Vector{}, Vector{1, 1, 1}), []Intersection{}},
+		{"crossing", &Mesh{Triangles: []Triangle{flat, {{0.5, 0.5, -1}, {0.5, 0.5, 1}, {3, 3, 0}}}}, []Intersection{{0, 1, Vector{1, 1, 0}}}},
+		{"apart", &Mesh{Triangles: []Triangle{flat, {{0.5, 0.5, 1}, {0.5, 0.5, 2}, {3, 3, 1}}}}, []Intersection{}},
+		{"sharing a vertex", &Mesh{Triangles: []Triangle{flat, {{0, 0, 0}, {0.5, 0.5, 1}, {1, 0.5, -1}}}}, []Intersection{}},
+		{"vertex within tolerance", &Mesh{Triangles: []Triangle{flat, {{0, 0, 1e-6}, {0.5, 0.5, 1}, {1, 0.5, -1}}}}, []Intersection{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			intersections := test.mesh.Intersections(1e-4)
+			if len(intersections) != len(test.want) {
+				t.Fatalf("got %v, want %v", intersections, test.want)
+			}
+			for i, intersection := range intersections {
+				want := test.want[i]
+				if intersection.First != want.First || intersection.Second != want.Second || intersection.Point.Sub(want.Point).Length() > 1e-9 {
+					t.Errorf("got %v, want %v", intersection, want)
+				}
+			}
+		})
+	}
+}
+
+func TestIntersectionsWhere(t *testing.T) {
+	crossing := &Mesh{Triangles: []Triangle{{{0, 0, 0}, {2, 0, 0}, {0, 2, 0}}, {{0.5, 0.5, -1}, {0.5, 0.5, 1}, {3, 3, 0}}}}
+	tests := []struct {
+		name string
+		test func(first, second int) bool
+		want int
+	}{
+		{"all pairs", nil, 1},
+		{"accepted pair", func(first, second int) bool { return first == 0 && second == 1 }, 1},
+		{"rejected pair", func(first, second int) bool { return false }, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if intersections := crossing.IntersectionsWhere(1e-4, test.test); len(intersections) != test.want {
+				t.Errorf("got %v, want %d intersections", intersections, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package mesh
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	stlHeaderSize   = 80
+	stlTriangleSize = 50
+)
+
+// ReadSTL reads an ASCII or binary STL file
+func ReadSTL(reader io.Reader) (*Mesh, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	// Binary files may also start with "solid", so check whether the size matches the triangle count first
+	if len(data) >= stlHeaderSize+4 {
+		count := binary.LittleEndian.Uint32(data[stlHeaderSize:])
+		if uint64(len(data)) == stlHeaderSize+4+uint64(count)*stlTriangleSize {
+			return readBinarySTL(data[stlHeaderSize+4:], int(count)), nil
+		}
+	}
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("solid")) {
+		return nil, errors.New("not an STL file")
+	}
+	return readASCIISTL(data)
+}
+
+func readBinarySTL(data []byte, count int) *Mesh {
+	mesh := &Mesh{Triangles: make([]Triangle, count)}
+	value := func(offset int) float64 {
+		return float64(math.Float32frombits(binary.LittleEndian.Uint32(data[offset:])))
+	}
+	for i := range mesh.Triangles {
+		// Each triangle is a normal, three vertices and a 2 byte attribute. The normal is recomputed from the vertices.
+		offset := i*stlTriangleSize + 12
+		for v := range mesh.Triangles[i] {
+			mesh.Triangles[i][v] = Vector{value(offset), value(offset + 4), value(offset + 8)}
+			offset += 12
+		}
+	}
+	return mesh
+}
+
+func readASCIISTL(data []byte) (*Mesh, error) {
+	mesh := &Mesh{Triangles: make([]Triangle, 0)}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	vertices := make([]Vector, 0, 3)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "vertex":
+			if len(fields) != 4 {
+				return nil, fmt.Errorf("line %d: vertex requires 3 coordinates", line)
+			}
+			coordinates := make([]float64, 3)
+			for i := range coordinates {
+				value, err := strconv.ParseFloat(fields[i+1], 64)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %w", line, err)
+				}
+				coordinates[i] = value
+			}
+			vertices = append(vertices, Vector{coordinates[0], coordinates[1], coordinates[2]})
+		case "endloop":
+			if len(vertices) != 3 {
+				return nil, fmt.Errorf("line %d: facet has %d vertices", line, len(vertices))
+			}
+			mesh.Triangles = append(mesh.Triangles, Triangle{vertices[0], vertices[1], vertices[2]})
+			vertices = vertices[:0]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return mesh, nil
+}
@@ -0,0 +1,420 @@
+// Package selector parses compact selector strings for choosing faces, edges and vertices, such as ">Z", "|Z and <X"
+// or "%CIRCLE". A parsed selector is a tree of [Node]s which the list types of makercad and its sketcher package evaluate
+// with their filters and sorters.
+//
+// The syntax is:
+//
+//	>D, <D     the elements furthest along or against direction D. >D[n] selects the n-th furthest instead (counting from 0;
+//	           negative indexes count back from the nearest)
+//	|D         lines parallel to D and planar faces with a normal parallel to D
+//	#D         lines perpendicular to D and planar faces with a normal perpendicular to D
+//	+D, -D     lines running in (or against) direction D and planar faces with a normal in (or against) direction D
+//	%TYPE      elements of a geometry type, such as %PLANE, %CYLINDER, %LINE or %CIRCLE
+//	a and b    elements selected by both a and b
+//	a or b     elements selected by either a or b
+//	not a      elements not selected by a
+//	( a )      grouping
+//
+// Directions are X, Y, Z or a vector such as (1, 1, 0). Keywords, axes and types are not case sensitive.
+package selector
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Direction is a direction in global coordinates. It is not normalized.
+type Direction struct {
+	X, Y, Z float64
+}
+
+func (d Direction) String() string {
+	switch d {
+	case Direction{1, 0, 0}:
+		return "X"
+	case Direction{0, 1, 0}:
+		return "Y"
+	case Direction{0, 0, 1}:
+		return "Z"
+	}
+	format := func(value float64) string { return strconv.FormatFloat(value, 'g', -1, 64) }
+	return "(" + format(d.X) + ", " + format(d.Y) + ", " + format(d.Z) + ")"
+}
+
+// Node is an element of a parsed selector: [Extreme], [Parallel], [Perpendicular], [Facing], [Type], [And], [Or] or [Not].
+// The String form of a node is the selector it was parsed from in canonical form.
+type Node interface {
+	fmt.Stringer
+	node()
+}
+
+// Extreme selects the elements whose center is furthest along (Maximum) or against the direction.
+// Elements at the same distance are selected together. Index chooses the next group of elements instead.
+type Extreme struct {
+	Direction Direction
+	Maximum   bool
+	Index     int
+}
+
+// Parallel selects lines and planar faces aligned with the direction
+type Parallel struct {
+	Direction Direction
+}
+
+// Perpendicular selects lines and planar faces perpendicular to the direction
+type Perpendicular struct {
+	Direction Direction
+}
+
+// Facing selects lines running in and planar faces facing in the direction, or against it when Opposite is set
+type Facing struct {
+	Direction Direction
+	Opposite  bool
+}
+
+// Type selects elements by the type of their geometry. Name is upper case.
+type Type struct {
+	Name string
+}
+
+// And selects the elements selected by both operands
+type And struct {
+	Left, Right Node
+}
+
+// Or selects the elements selected by either operand
+type Or struct {
+	Left, Right Node
+}
+
+// Not selects the elements its operand does not select
+type Not struct {
+	Operand Node
+}
+
+func (Extreme) node()       {}
+func (Parallel) node()      {}
+func (Perpendicular) node() {}
+func (Facing) node()        {}
+func (Type) node()          {}
+func (And) node()           {}
+func (Or) node()            {}
+func (Not) node()           {}
+
+func (n Extreme) String() string {
+	operator := "<"
+	if n.Maximum {
+		operator = ">"
+	}
+	if n.Index != 0 {
+		return fmt.Sprintf("%s%s[%d]", operator, n.Direction, n.Index)
+	}
+	return operator + n.Direction.String()
+}
+
+func (n Parallel) String() string      { return "|" + n.Direction.String() }
+func (n Perpendicular) String() string { return "#" + n.Direction.String() }
+func (n Type) String() string          { return "%" + n.Name }
+func (n And) String() string           { return "(" + n.Left.String() + " and " + n.Right.String() + ")" }
+func (n Or) String() string            { return "(" + n.Left.String() + " or " + n.Right.String() + ")" }
+func (n Not) String() string           { return "not " + n.Operand.String() }
+
+func (n Facing) String() string {
+	if n.Opposite {
+		return "-" + n.Direction.String()
+	}
+	return "+" + n.Direction.String()
+}
+
+// Evaluate applies a selector to items. The boolean operators are evaluated here and every other node is passed to
+// leaf, which returns the items it selects. The result keeps the order of items.
+func Evaluate[S ~[]T, T comparable](node Node, items S, leaf func(node Node, items S) (S, error)) (S, error) {
+	var selected S
+	var err error
+	switch n := node.(type) {
+	case And:
+		var left, right S
+		if left, err = Evaluate(n.Left, items, leaf); err != nil {
+			return nil, err
+		}
+		if right, err = Evaluate(n.Right, items, leaf); err != nil {
+			return nil, err
+		}
+		selected = slices.DeleteFunc(left, func(item T) bool { return !slices.Contains(right, item) })
+	case Or:
+		var left, right S
+		if left, err = Evaluate(n.Left, items, leaf); err != nil {
+			return nil, err
+		}
+		if right, err = Evaluate(n.Right, items, leaf); err != nil {
+			return nil, err
+		}
+		selected = append(left, right...)
+	case Not:
+		var operand S
+		if operand, err = Evaluate(n.Operand, items, leaf); err != nil {
+			return nil, err
+		}
+		selected = slices.DeleteFunc(slices.Clone(items), func(item T) bool { return slices.Contains(operand, item) })
+	default:
+		if selected, err = leaf(node, items); err != nil {
+			return nil, err
+		}
+	}
+
+	result := make(S, 0, len(selected))
+	for _, item := range items {
+		if slices.Contains(selected, item) && !slices.Contains(result, item) {
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
+
+// Group returns the index-th group of consecutive items of a sorted list which are the same according to same.
+// A negative index counts back from the last group. Nothing is returned when there are not enough groups.
+func Group[S ~[]T, T any](sorted S, same func(a, b T) bool, index int) S {
+	groups := make([]S, 0)
+	for i, item := range sorted {
+		if i == 0 || !same(sorted[i-1], item) {
+			groups = append(groups, make(S, 0, 1))
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], item)
+	}
+	if index < 0 {
+		index += len(groups)
+	}
+	if index < 0 || index >= len(groups) {
+		return S{}
+	}
+	return groups[index]
+}
+
+// SyntaxError describes why a selector could not be parsed
+type SyntaxError struct {
+	Selector string
+	Offset   int
+	Message  string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("selector %q: %s at offset %d", e.Selector, e.Message, e.Offset)
+}
+
+// Parse parses a selector string. Errors are of type [*SyntaxError].
+func Parse(selector string) (Node, error) {
+	p := &parser{source: selector}
+	node, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.offset < len(p.source) {
+		return nil, p.errorf("unexpected %q", p.source[p.offset:])
+	}
+	return node, nil
+}
+
+type parser struct {
+	source string
+	offset int
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return &SyntaxError{Selector: p.source, Offset: p.offset, Message: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) skipSpace() {
+	for p.offset < len(p.source) && strings.ContainsRune(" \t\r\n", rune(p.source[p.offset])) {
+		p.offset++
+	}
+}
+
+// peek returns the next character after any space, or 0 at the end of the selector
+func (p *parser) peek() byte {
+	p.skipSpace()
+	if p.offset >= len(p.source) {
+		return 0
+	}
+	return p.source[p.offset]
+}
+
+// word returns the letters, digits and underscores at the current position without consuming them
+func (p *parser) word() string {
+	p.skipSpace()
+	end := p.offset
+	for end < len(p.source) {
+		c := p.source[end]
+		if !(c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9') {
+			break
+		}
+		end++
+	}
+	return p.source[p.offset:end]
+}
+
+// keyword consumes the keyword if it is next
+func (p *parser) keyword(keyword string) bool {
+	word := p.word()
+	if !strings.EqualFold(word, keyword) {
+		return false
+	}
+	p.offset += len(word)
+	return true
+}
+
+func (p *parser) or() (Node, error) {
+	node, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		node = Or{node, right}
+	}
+	return node, nil
+}
+
+func (p *parser) and() (Node, error) {
+	node, err := p.not()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and") {
+		right, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		node = And{node, right}
+	}
+	return node, nil
+}
+
+func (p *parser) not() (Node, error) {
+	if p.keyword("not") {
+		operand, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		return Not{operand}, nil
+	}
+	return p.term()
+}
+
+func (p *parser) term() (Node, error) {
+	operator := p.peek()
+	start := p.offset
+	p.offset++
+	switch operator {
+	case '(':
+		node, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ')' {
+			return nil, p.errorf("expected )")
+		}
+		p.offset++
+		return node, nil
+	case '%':
+		name := p.word()
+		if name == "" {
+			return nil, p.errorf("expected a type name after %%")
+		}
+		p.offset += len(name)
+		return Type{strings.ToUpper(name)}, nil
+	case '>', '<', '|', '#', '+', '-':
+		direction, err := p.direction()
+		if err != nil {
+			return nil, err
+		}
+		switch operator {
+		case '|':
+			return Parallel{direction}, nil
+		case '#':
+			return Perpendicular{direction}, nil
+		case '+', '-':
+			return Facing{direction, operator == '-'}, nil
+		}
+		extreme := Extreme{Direction: direction, Maximum: operator == '>'}
+		if p.peek() == '[' {
+			p.offset++
+			number := p.number()
+			index, err := strconv.Atoi(number)
+			if err != nil {
+				return nil, p.errorf("expected an integer index")
+			}
+			p.offset += len(number)
+			if p.peek() != ']' {
+				return nil, p.errorf("expected ]")
+			}
+			p.offset++
+			extreme.Index = index
+		}
+		return extreme, nil
+	case 0:
+		p.offset = start
+		return nil, p.errorf("unexpected end of selector")
+	}
+	p.offset = start
+	return nil, p.errorf("unexpected %q, expected a selector such as >Z, |X, %%PLANE, not or (", operator)
+}
+
+// number returns the number at the current position without consuming it
+func (p *parser) number() string {
+	p.skipSpace()
+	end := p.offset
+	for end < len(p.source) && strings.ContainsRune("+-.0123456789eE", rune(p.source[end])) {
+		end++
+	}
+	return p.source[p.offset:end]
+}
+
+func (p *parser) direction() (Direction, error) {
+	if p.peek() != '(' {
+		axis := p.word()
+		p.offset += len(axis)
+		switch strings.ToUpper(axis) {
+		case "X":
+			return Direction{1, 0, 0}, nil
+		case "Y":
+			return Direction{0, 1, 0}, nil
+		case "Z":
+			return Direction{0, 0, 1}, nil
+		}
+		p.offset -= len(axis)
+		return Direction{}, p.errorf("expected a direction X, Y, Z or (x, y, z)")
+	}
+
+	p.offset++
+	components := make([]float64, 3)
+	for i := range components {
+		if i > 0 {
+			if p.peek() != ',' {
+				return Direction{}, p.errorf("expected ,")
+			}
+			p.offset++
+		}
+		number := p.number()
+		value, err := strconv.ParseFloat(number, 64)
+		if err != nil {
+			return Direction{}, p.errorf("expected a number")
+		}
+		p.offset += len(number)
+		components[i] = value
+	}
+	if p.peek() != ')' {
+		return Direction{}, p.errorf("expected )")
+	}
+	p.offset++
+	direction := Direction{components[0], components[1], components[2]}
+	if direction == (Direction{}) {
+		return Direction{}, p.errorf("direction must not be zero")
+	}
+	return direction, nil
+}
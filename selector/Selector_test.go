@@ -0,0 +1,141 @@
+package selector
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		selector string
+		want     Node
+		string   string
+	}{
+		{">Z", Extreme{Direction{0, 0, 1}, true, 0}, ">Z"},
+		{"<x[-1]", Extreme{Direction{1, 0, 0}, false, -1}, "<X[-1]"},
+		{"|Y", Parallel{Direction{0, 1, 0}}, "|Y"},
+		{"#(1, 1, 0)", Perpendicular{Direction{1, 1, 0}}, "#(1, 1, 0)"},
+		{"-Z", Facing{Direction{0, 0, 1}, true}, "-Z"},
+		{"%circle", Type{"CIRCLE"}, "%CIRCLE"},
+		{"|Z and <X", And{Parallel{Direction{0, 0, 1}}, Extreme{Direction{1, 0, 0}, false, 0}}, "(|Z and <X)"},
+		{"%LINE or %ARC and not >Z", Or{Type{"LINE"}, And{Type{"ARC"}, Not{Extreme{Direction{0, 0, 1}, true, 0}}}}, "(%LINE or (%ARC and not >Z))"},
+		{"not (+X or +Y)", Not{Or{Facing{Direction{1, 0, 0}, false}, Facing{Direction{0, 1, 0}, false}}}, "not (+X or +Y)"},
+		{">(0.5, -1e-3, 2)[2]", Extreme{Direction{0.5, -0.001, 2}, true, 2}, ">(0.5, -0.001, 2)[2]"},
+	}
+	for _, test := range tests {
+		t.Run(test.selector, func(t *testing.T) {
+			node, err := Parse(test.selector)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(node, test.want) {
+				t.Errorf("got %#v, want %#v", node, test.want)
+			}
+			if node.String() != test.string {
+				t.Errorf("String() is %q, want %q", node.String(), test.string)
+			}
+
+			// The String form parses back to the same selector
+			reparsed, err := Parse(node.String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(reparsed, node) {
+				t.Errorf("%q parses to %#v, want %#v", node.String(), reparsed, node)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		selector string
+		offset   int
+	}{
+		{"", 0},
+		{">W", 1},
+		{"|Z and", 6},
+		{"(>Z", 3},
+		{">(1, 0)", 6},
+		{">(0, 0, 0)", 10},
+		{"<X[a]", 3},
+		{"%", 1},
+		{">Z >X", 3},
+		{"@Z", 0},
+	}
+	for _, test := range tests {
+		t.Run(test.selector, func(t *testing.T) {
+			_, err := Parse(test.selector)
+			var syntaxError *SyntaxError
+			if !errors.As(err, &syntaxError) {
+				t.Fatalf("got error %v, want a *SyntaxError", err)
+			}
+			if syntaxError.Offset != test.offset {
+				t.Errorf("error %q at offset %d, want offset %d", err, syntaxError.Offset, test.offset)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	// Leaf nodes select the numbers of a type: %EVEN, %ODD or %SMALL (below 3)
+	leaf := func(node Node, items []int) ([]int, error) {
+		return slices.DeleteFunc(slices.Clone(items), func(i int) bool {
+			switch node.(Type).Name {
+			case "EVEN":
+				return i%2 != 0
+			case "ODD":
+				return i%2 == 0
+			}
+			return i >= 3
+		}), nil
+	}
+	items := []int{0, 1, 2, 3, 4, 5}
+	tests := []struct {
+		selector string
+		want     []int
+	}{
+		{"%EVEN", []int{0, 2, 4}},
+		{"%EVEN and %SMALL", []int{0, 2}},
+		{"%ODD or %SMALL", []int{0, 1, 2, 3, 5}},
+		{"not %SMALL", []int{3, 4, 5}},
+		{"not (%EVEN or %ODD)", []int{}},
+	}
+	for _, test := range tests {
+		t.Run(test.selector, func(t *testing.T) {
+			node, err := Parse(test.selector)
+			if err != nil {
+				t.Fatal(err)
+			}
+			selected, err := Evaluate(node, items, leaf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(selected, test.want) {
+				t.Errorf("got %v, want %v", selected, test.want)
+			}
+		})
+	}
+}
+
+func TestGroup(t *testing.T) {
+	sorted := []float64{1, 1, 2, 3, 3, 3}
+	same := func(a, b float64) bool { return a == b }
+	tests := []struct {
+		index int
+		want  []float64
+	}{
+		{0, []float64{1, 1}},
+		{1, []float64{2}},
+		{-1, []float64{3, 3, 3}},
+		{3, []float64{}},
+		{-4, []float64{}},
+	}
+	for _, test := range tests {
+		if got := Group(sorted, same, test.index); !slices.Equal(got, test.want) {
+			t.Errorf("group %d is %v, want %v", test.index, got, test.want)
+		}
+	}
+}
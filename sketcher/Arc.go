@@ -47,6 +47,24 @@ func (a *Arc) Tangent(l *Line) *Arc {
 	return a
 }
 
+// Mirrored creates a copy of this arc mirrored across axis. Mirroring reverses the arc's direction, so the copy's start mirrors this arc's end.
+func (a *Arc) Mirrored(axis *Line) *Arc {
+	return a.mirrored(&pointMirrors{axis: axis})
+}
+
+func (a *Arc) mirrored(mirrors *pointMirrors) *Arc {
+	centerX, centerY, _ := mirrors.axis.reflect(a.Center.X, a.Center.Y)
+	startX, startY, _ := mirrors.axis.reflect(a.End.X, a.End.Y)
+	endX, endY, _ := mirrors.axis.reflect(a.Start.X, a.Start.Y)
+	mirrored := a.solver.CreateArc(centerX, centerY, startX, startY, endX, endY)
+	mirrored.SetConstruction(a.isConstruction)
+	mirrors.constrain(a.Center, mirrored.Center)
+	mirrors.constrain(a.End, mirrored.Start)
+	mirrors.constrain(a.Start, mirrored.End)
+
+	return mirrored
+}
+
 // MakeEdge generates an edge from the sketch element. Usually this is handled by MakerCad.
 func (a *Arc) MakeEdge() *Edge {
 	centerPoint := a.Center.Convert()
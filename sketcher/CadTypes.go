@@ -88,6 +88,25 @@ func (p *PlaneParameters) Ax2() gp.Ax2 {
 	)
 }
 
+// RotatedBy returns a copy of the plane rotated by the angle in radians about an axis through an origin. A nil axis
+// rotates about the plane's normal and a nil origin rotates about the plane's location.
+func (p *PlaneParameters) RotatedBy(angle float64, axis *Vector, origin *Vector) *PlaneParameters {
+	if axis == nil {
+		axis = NewVector(p.Normal.ToVector())
+	}
+	if origin == nil {
+		origin = p.Location
+	}
+	coordinates := p.Plane()
+	coordinates.Rotate(gp.NewAx1(origin.ToPoint(), gp.NewDirVec(axis.ToVector())), angle)
+	return NewPlaneParametersFromCoordinateSystem(coordinates)
+}
+
+// Rotated returns a copy of the plane rotated by an angle in degrees about an optional axis and origin (see
+// [PlaneParameters.RotatedBy]).
+//
+// Deprecated: Rotated takes degrees unlike every other angle in MakerCad and panics on arguments of the wrong type.
+// Use [PlaneParameters.RotatedBy], which takes radians.
 func (p *PlaneParameters) Rotated(a ...interface{}) *PlaneParameters {
 	argc := len(a)
 	if argc < 1 || argc > 3 {
@@ -95,28 +114,33 @@ func (p *PlaneParameters) Rotated(a ...interface{}) *PlaneParameters {
 	}
 	degrees := a[0].(float64)
 	var axis, origin *Vector
-
-	if argc < 2 {
-		// calculate axis
-		axis = NewVector(p.Normal.ToVector())
-	} else {
+	if argc > 1 {
 		axis = a[1].(*Vector)
 	}
-	if argc < 3 {
-		// use current location as origin
-		origin = p.Location
-	} else {
+	if argc > 2 {
 		origin = a[2].(*Vector)
 	}
-	coordinates := p.Plane()
-	coordinates.Rotate(gp.NewAx1(origin.ToPoint(), gp.NewDirVec(axis.ToVector())), utils.ToRadians(degrees))
-	return NewPlaneParametersFromCoordinateSystem(coordinates)
+	return p.RotatedBy(utils.ToRadians(degrees), axis, origin)
 }
 
 func (p *PlaneParameters) Translated(dir Vector) *PlaneParameters {
 	return NewPlaneParametersFromCoordinateSystem(p.Plane().Translated(dir.ToVector()))
 }
 
+// Axis is a line in space through Origin along Direction, such as an axis of rotation
+type Axis struct {
+	Origin    *Vector
+	Direction *Vector
+}
+
+func NewAxis(origin *Vector, direction *Vector) *Axis {
+	return &Axis{origin, direction}
+}
+
+func (a *Axis) Ax1() gp.Ax1 {
+	return gp.NewAx1(a.Origin.ToPoint(), gp.NewDirVec(a.Direction.ToVector()))
+}
+
 func (p *PlaneParameters) String() string {
 	return fmt.Sprintf("{ location: %s, normal: %s, x dir: %s }\n", p.Location.ToString(), p.Normal.ToString(), p.X.ToString())
 }
@@ -46,6 +46,21 @@ func (c *Circle) Equal(other *Circle) *Circle {
 	return c
 }
 
+// Mirrored creates a copy of this circle mirrored across axis with an equal diameter and a center symmetric to this circle's center
+func (c *Circle) Mirrored(axis *Line) *Circle {
+	return c.mirrored(&pointMirrors{axis: axis})
+}
+
+func (c *Circle) mirrored(mirrors *pointMirrors) *Circle {
+	centerX, centerY, _ := mirrors.axis.reflect(c.Center.X, c.Center.Y)
+	mirrored := c.solver.CreateCircle(centerX, centerY, c.Radius)
+	mirrored.SetConstruction(c.isConstruction)
+	mirrors.constrain(c.Center, mirrored.Center)
+	mirrored.Equal(c)
+
+	return mirrored
+}
+
 // UpdateFromValues updates the element's center, start, and end based on the current sketch values
 // Automatically called when the sketch is solved
 func (c *Circle) UpdateFromValues() {
@@ -0,0 +1,74 @@
+package sketcher
+
+// ConstraintType identifies the kind of a [Constraint]
+type ConstraintType int
+
+const (
+	ConstraintCoincident ConstraintType = iota
+	ConstraintPointVerticalDistance
+	ConstraintPointHorizontalDistance
+	ConstraintPointProjectedDistance
+	ConstraintLineMidpoint
+	ConstraintLineAngle
+	ConstraintArcLineTangent
+	ConstraintDistance
+	ConstraintHorizontalLine
+	ConstraintHorizontalPoints
+	ConstraintVerticalLine
+	ConstraintVerticalPoints
+	ConstraintPointSymmetric
+	ConstraintLineLength
+	ConstraintEqual
+	ConstraintCurveDiameter
+	ConstraintFixed
+)
+
+func (t ConstraintType) String() string {
+	switch t {
+	case ConstraintCoincident:
+		return "Coincident"
+	case ConstraintPointVerticalDistance:
+		return "PointVerticalDistance"
+	case ConstraintPointHorizontalDistance:
+		return "PointHorizontalDistance"
+	case ConstraintPointProjectedDistance:
+		return "PointProjectedDistance"
+	case ConstraintLineMidpoint:
+		return "LineMidpoint"
+	case ConstraintLineAngle:
+		return "LineAngle"
+	case ConstraintArcLineTangent:
+		return "ArcLineTangent"
+	case ConstraintDistance:
+		return "Distance"
+	case ConstraintHorizontalLine:
+		return "HorizontalLine"
+	case ConstraintHorizontalPoints:
+		return "HorizontalPoints"
+	case ConstraintVerticalLine:
+		return "VerticalLine"
+	case ConstraintVerticalPoints:
+		return "VerticalPoints"
+	case ConstraintPointSymmetric:
+		return "PointSymmetric"
+	case ConstraintLineLength:
+		return "LineLength"
+	case ConstraintEqual:
+		return "Equal"
+	case ConstraintCurveDiameter:
+		return "CurveDiameter"
+	case ConstraintFixed:
+		return "Fixed"
+	}
+	return "Unknown"
+}
+
+// Constraint records a constraint as it was requested from a [SketchSolver].
+// Entities are in the order of the solver method's arguments. Value holds the distance, angle (radians) or diameter when the constraint has one.
+// Constraints a solver adds internally to implement another constraint are not recorded; Created lists the construction entities it added for them.
+type Constraint struct {
+	Type     ConstraintType
+	Entities []Entity
+	Value    float64
+	Created  []Entity
+}
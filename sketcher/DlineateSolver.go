@@ -13,10 +13,15 @@ type DlineateSolver struct {
 	origin           *Point
 	xAxis            *Line
 	yAxis            *Line
+	constraints      []*Constraint
+	// building counts the constraint methods in progress so constraints used to build another are not recorded
+	building int
+	// current is the recorded constraint being built
+	current *Constraint
 }
 
 func NewDlineateSolver(planer Planer) *DlineateSolver {
-	solver := &DlineateSolver{dlineate.NewSketch(), make([]Entity, 0), planer.Plane(), nil, nil, nil}
+	solver := &DlineateSolver{dlineate.NewSketch(), make([]Entity, 0), planer.Plane(), nil, nil, nil, make([]*Constraint, 0), 0, nil}
 	solver.origin = &Point{Element: *solver.system.Origin, solver: solver, X: 0, Y: 0, isConstruction: true}
 	solver.xAxis = &Line{Element: *solver.system.XAxis, solver: solver, Start: nil, End: nil, isConstruction: true}
 	solver.yAxis = &Line{Element: *solver.system.YAxis, solver: solver, Start: nil, End: nil, isConstruction: true}
@@ -27,6 +32,30 @@ func (s *DlineateSolver) Entities() []Entity {
 	return s.entities
 }
 
+// Constraints returns the constraints added to the sketch in the order they were added
+func (s *DlineateSolver) Constraints() []*Constraint {
+	return s.constraints
+}
+
+// record adds a constraint unless it is being added to build another one.
+// The returned function must be called once the constraint has been added.
+func (s *DlineateSolver) record(t ConstraintType, value float64, entities ...Entity) func() {
+	if s.building == 0 {
+		s.current = &Constraint{Type: t, Entities: entities, Value: value}
+		s.constraints = append(s.constraints, s.current)
+	}
+	s.building++
+	return func() { s.building-- }
+}
+
+// add includes a new entity in the sketch, noting it as created by the constraint being built if there is one
+func (s *DlineateSolver) add(entity Entity) {
+	s.entities = append(s.entities, entity)
+	if s.building > 0 {
+		s.current.Created = append(s.current.Created, entity)
+	}
+}
+
 func (s *DlineateSolver) Origin() *Point {
 	return s.origin
 }
@@ -41,7 +70,7 @@ func (s *DlineateSolver) YAxis() *Line {
 
 func (s *DlineateSolver) CreatePoint(x float64, y float64) *Point {
 	entity := &Point{Element: *s.system.AddPoint(x, y), solver: s, X: x, Y: y, isConstruction: false}
-	s.entities = append(s.entities, entity)
+	s.add(entity)
 	return entity
 }
 
@@ -53,14 +82,14 @@ func (s *DlineateSolver) CreateLine(p1X float64, p1Y float64, p2X float64, p2Y f
 	entity := &Line{Element: *s.system.AddLine(p1X, p1Y, p2X, p2Y), solver: s, isConstruction: false}
 	entity.Start = s.PointFromRef(entity.Element.Start())
 	entity.End = s.PointFromRef(entity.Element.End())
-	s.entities = append(s.entities, entity)
+	s.add(entity)
 	return entity
 }
 
 func (s *DlineateSolver) CreateCircle(centerX float64, centerY float64, r float64) *Circle {
 	entity := &Circle{Element: *s.system.AddCircle(centerX, centerY, r), solver: s, Radius: r, isConstruction: false}
 	entity.Center = s.PointFromRef(entity.Element.Center())
-	s.entities = append(s.entities, entity)
+	s.add(entity)
 	return entity
 }
 
@@ -73,11 +102,12 @@ func (s *DlineateSolver) CreateArc(centerX float64, centerY float64, startX floa
 	entity.Start = s.PointFromRef(entity.Element.Start())
 	entity.Center = s.PointFromRef(entity.Element.Center())
 	entity.End = s.PointFromRef(entity.Element.End())
-	s.entities = append(s.entities, entity)
+	s.add(entity)
 	return entity
 }
 
 func (s *DlineateSolver) Coincident(e1 Entity, e2 Entity) {
+	defer s.record(ConstraintCoincident, 0, e1, e2)()
 	_, isE1Point := e1.(*Point)
 	_, isE2Point := e2.(*Point)
 	if isE1Point && isE2Point {
@@ -89,6 +119,7 @@ func (s *DlineateSolver) Coincident(e1 Entity, e2 Entity) {
 }
 
 func (s *DlineateSolver) PointVerticalDistance(p *Point, e Entity, d float64) {
+	defer s.record(ConstraintPointVerticalDistance, d, p, e)()
 	// Special case if constraining against origin, use x axis
 	if s.origin.getElement().ID() == e.getElement().ID() || s.xAxis.getElement().ID() == e.getElement().ID() {
 		p.Distance(s.xAxis, d)
@@ -120,6 +151,7 @@ func (s *DlineateSolver) PointVerticalDistance(p *Point, e Entity, d float64) {
 }
 
 func (s *DlineateSolver) PointHorizontalDistance(p *Point, e Entity, d float64) {
+	defer s.record(ConstraintPointHorizontalDistance, d, p, e)()
 	// e is some line
 	// Create a horizontally constrained line from p to e; set distance
 	// Special case if constraining against origin, use y axis
@@ -154,6 +186,7 @@ func (s *DlineateSolver) PointHorizontalDistance(p *Point, e Entity, d float64)
 }
 
 func (s *DlineateSolver) PointProjectedDistance(p *Point, e Entity, d float64) {
+	defer s.record(ConstraintPointProjectedDistance, d, p, e)()
 	pe, ok := e.(*Point)
 	if !ok {
 		pe = s.CreatePoint(0, 0)
@@ -169,26 +202,32 @@ func (s *DlineateSolver) PointProjectedDistance(p *Point, e Entity, d float64) {
 }
 
 func (s *DlineateSolver) LineMidpoint(l *Line, e Entity) {
+	defer s.record(ConstraintLineMidpoint, 0, l, e)()
 	s.system.AddMidpointConstraint(e.getElement(), l.getElement())
 }
 
 func (s *DlineateSolver) LineAngle(l1 *Line, l2 *Line, d float64) {
+	defer s.record(ConstraintLineAngle, d, l1, l2)()
 	s.system.AddAngleConstraint(l1.getElement(), l2.getElement(), d, false)
 }
 
 func (s *DlineateSolver) ArcLineTangent(a *Arc, l *Line) {
+	defer s.record(ConstraintArcLineTangent, 0, a, l)()
 	s.system.AddTangentConstraint(a.getElement(), l.getElement())
 }
 
 func (s *DlineateSolver) Distance(e1 Entity, e2 Entity, d float64) {
+	defer s.record(ConstraintDistance, d, e1, e2)()
 	s.system.AddDistanceConstraint(e1.getElement(), e2.getElement(), d)
 }
 
 func (s *DlineateSolver) HorizontalLine(l *Line) {
+	defer s.record(ConstraintHorizontalLine, 0, l)()
 	s.system.AddHorizontalConstraint(l.getElement())
 }
 
 func (s *DlineateSolver) HorizontalPoints(p1 *Point, p2 *Point) {
+	defer s.record(ConstraintHorizontalPoints, 0, p1, p2)()
 	hl := s.CreateLine(p1.X, p1.Y, p2.X, p2.Y)
 	hl.isConstruction = true
 	s.system.AddCoincidentConstraint(hl.getElement(), p1.getElement())
@@ -197,10 +236,12 @@ func (s *DlineateSolver) HorizontalPoints(p1 *Point, p2 *Point) {
 }
 
 func (s *DlineateSolver) VerticalLine(l *Line) {
+	defer s.record(ConstraintVerticalLine, 0, l)()
 	s.system.AddVerticalConstraint(l.getElement())
 }
 
 func (s *DlineateSolver) VerticalPoints(p1 *Point, p2 *Point) {
+	defer s.record(ConstraintVerticalPoints, 0, p1, p2)()
 	vl := s.CreateLine(p1.X, p1.Y, p2.X, p2.Y)
 	vl.isConstruction = true
 	s.system.AddCoincidentConstraint(vl.getElement().Start(), p1.getElement())
@@ -208,15 +249,32 @@ func (s *DlineateSolver) VerticalPoints(p1 *Point, p2 *Point) {
 	s.system.AddVerticalConstraint(vl.getElement())
 }
 
+func (s *DlineateSolver) PointSymmetric(p1 *Point, p2 *Point, axis *Line) {
+	defer s.record(ConstraintPointSymmetric, 0, p1, p2, axis)()
+	// A construction line between the points is perpendicular to the axis and bisected by it
+	sl := s.CreateLine(p1.X, p1.Y, p2.X, p2.Y)
+	sl.isConstruction = true
+	s.system.AddCoincidentConstraint(sl.getElement().Start(), p1.getElement())
+	s.system.AddCoincidentConstraint(sl.getElement().End(), p2.getElement())
+	s.system.AddPerpendicularConstraint(sl.getElement(), axis.getElement())
+	mid := s.CreatePoint((p1.X+p2.X)/2, (p1.Y+p2.Y)/2)
+	mid.isConstruction = true
+	s.system.AddMidpointConstraint(mid.getElement(), sl.getElement())
+	s.system.AddDistanceConstraint(mid.getElement(), axis.getElement(), 0)
+}
+
 func (s *DlineateSolver) LineLength(l *Line, d float64) {
+	defer s.record(ConstraintLineLength, d, l)()
 	s.system.AddDistanceConstraint(l.Start.getElement(), l.End.getElement(), d)
 }
 
 func (s *DlineateSolver) Equal(e1 Entity, e2 Entity) {
+	defer s.record(ConstraintEqual, 0, e1, e2)()
 	s.system.AddEqualConstraint(e1.getElement(), e2.getElement())
 }
 
 func (s *DlineateSolver) CurveDiameter(e Entity, d float64) {
+	defer s.record(ConstraintCurveDiameter, d, e)()
 	a, aok := e.(*Arc)
 	c, cok := e.(*Circle)
 	if aok {
@@ -243,6 +301,7 @@ func (s *DlineateSolver) CoordinateSystem() gp.Ax3 {
 }
 
 func (s *DlineateSolver) MakeFixed(e Entity) {
+	defer s.record(ConstraintFixed, 0, e)()
 	s.system.MakeFixed(e.getElement())
 }
 
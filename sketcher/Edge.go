@@ -1,12 +1,18 @@
 package sketcher
 
 import (
+	"errors"
+	"math"
 	"slices"
 
 	"github.com/marcuswu/dlineate/utils"
+
 	"github.com/marcuswu/gooccwrapper/brepadapter"
+	"github.com/marcuswu/gooccwrapper/brepbuilderapi"
+	"github.com/marcuswu/gooccwrapper/brepgprop"
 	"github.com/marcuswu/gooccwrapper/gcpnts"
 	"github.com/marcuswu/gooccwrapper/gp"
+	"github.com/marcuswu/gooccwrapper/gprop"
 	"github.com/marcuswu/gooccwrapper/topexp"
 	"github.com/marcuswu/gooccwrapper/topods"
 )
@@ -69,6 +75,61 @@ func (l ListOfEdge) Parallel(dir gp.Dir) ListOfEdge {
 	})
 }
 
+// EdgeConvexity describes how the two faces meeting at an edge are joined
+type EdgeConvexity int
+
+const (
+	EdgeConvex   EdgeConvexity = iota // the faces form an outside corner
+	EdgeConcave                       // the faces form an inside corner
+	EdgeTangent                       // the faces continue smoothly, as at the seam of a cylinder or the side of a fillet
+	EdgeBoundary                      // the edge does not join two faces, as at the border of an open shell
+)
+
+func (c EdgeConvexity) String() string {
+	switch c {
+	case EdgeConvex:
+		return "Convex"
+	case EdgeConcave:
+		return "Concave"
+	case EdgeTangent:
+		return "Tangent"
+	case EdgeBoundary:
+		return "Boundary"
+	}
+	return "Unknown"
+}
+
+// ConvexityClassifier classifies edges by how the faces meeting at them are joined. makercad's Shape implements it.
+type ConvexityClassifier interface {
+	EdgeConvexities(edges ListOfEdge) []EdgeConvexity
+}
+
+// Convex filters the list by edges where the faces of the shape form an outside corner
+func (l ListOfEdge) Convex(shape ConvexityClassifier) ListOfEdge {
+	return l.withConvexity(shape, EdgeConvex)
+}
+
+// Concave filters the list by edges where the faces of the shape form an inside corner
+func (l ListOfEdge) Concave(shape ConvexityClassifier) ListOfEdge {
+	return l.withConvexity(shape, EdgeConcave)
+}
+
+// Tangent filters the list by edges where the faces of the shape continue smoothly
+func (l ListOfEdge) Tangent(shape ConvexityClassifier) ListOfEdge {
+	return l.withConvexity(shape, EdgeTangent)
+}
+
+func (l ListOfEdge) withConvexity(shape ConvexityClassifier, convexity EdgeConvexity) ListOfEdge {
+	convexities := shape.EdgeConvexities(l)
+	newList := make(ListOfEdge, 0, len(l))
+	for i, edge := range l {
+		if convexities[i] == convexity {
+			newList = append(newList, edge)
+		}
+	}
+	return newList
+}
+
 // Sort sorts the edges by the provided sorter function
 func (l ListOfEdge) Sort(sorter EdgeSorter) {
 	slices.SortFunc(l, sorter)
@@ -188,6 +249,30 @@ func (e *Edge) GetLine(solver SketchSolver) *Line {
 	ex.Next()
 	endX, endY := e.projectPointToSketch(solver, topods.NewVertexFromRef(topods.TopoDSVertex(ex.Current().Shape)).Pnt())
 
+	return fixedLine(solver, startX, startY, endX, endY)
+}
+
+// GetPolyline projects points along this edge to the specified sketch as a chain of lines through them. Points which
+// project onto the previous point are skipped, so a curve seen edge on becomes a few overlapping lines.
+func (e *Edge) GetPolyline(solver SketchSolver, points []Vector) []*Line {
+	lines := make([]*Line, 0, len(points))
+	if len(points) < 1 {
+		return lines
+	}
+	startX, startY := e.projectPointToSketch(solver, points[0].ToPoint())
+	for _, point := range points[1:] {
+		endX, endY := e.projectPointToSketch(solver, point.ToPoint())
+		if utils.StandardFloatCompare(math.Hypot(endX-startX, endY-startY), 0) == 0 {
+			continue
+		}
+		lines = append(lines, fixedLine(solver, startX, startY, endX, endY))
+		startX, startY = endX, endY
+	}
+	return lines
+}
+
+// fixedLine creates a line in the sketch fixed at its position
+func fixedLine(solver SketchSolver, startX, startY, endX, endY float64) *Line {
 	line := solver.CreateLine(startX, startY, endX, endY)
 	line.Start.VerticalDistance(solver.XAxis(), startY)
 	line.Start.HorizontalDistance(solver.YAxis(), startX)
@@ -225,6 +310,70 @@ func (e *Edge) GetCircle(solver SketchSolver) *Circle {
 	return circ
 }
 
+// GetArc projects this edge to the specified sketch if it is a circular arc
+func (e *Edge) GetArc(solver SketchSolver) *Arc {
+	if !e.IsArc() {
+		return nil
+	}
+
+	circle := brepadapter.NewCurve(e.Edge).ToCircle()
+	centerX, centerY := e.projectPointToSketch(solver, circle.Location())
+	firstX, firstY := e.projectPointToSketch(solver, e.FirstVertex())
+	lastX, lastY := e.projectPointToSketch(solver, e.LastVertex())
+
+	// Sketch arcs run counterclockwise from start to end. Choose the direction whose sweep matches the edge length.
+	// A half circle matches in both directions and is taken from the first vertex.
+	sweep := e.LineLength() / circle.Radius()
+	firstAngle := math.Atan2(firstY-centerY, firstX-centerX)
+	lastAngle := math.Atan2(lastY-centerY, lastX-centerX)
+	counterClockwise := math.Mod(lastAngle-firstAngle+2*math.Pi, 2*math.Pi)
+	startX, startY, endX, endY := firstX, firstY, lastX, lastY
+	if math.Abs(counterClockwise-sweep) > math.Abs((2*math.Pi-counterClockwise)-sweep) {
+		startX, startY, endX, endY = lastX, lastY, firstX, firstY
+	}
+
+	arc := solver.CreateArc(centerX, centerY, startX, startY, endX, endY)
+	arc.Center.VerticalDistance(solver.XAxis(), centerY)
+	arc.Center.HorizontalDistance(solver.YAxis(), centerX)
+	arc.Start.VerticalDistance(solver.XAxis(), startY)
+	arc.Start.HorizontalDistance(solver.YAxis(), startX)
+	arc.End.VerticalDistance(solver.XAxis(), endY)
+	arc.End.HorizontalDistance(solver.YAxis(), endX)
+	solver.MakeFixed(arc)
+	return arc
+}
+
+// IsArc returns whether this edge is a circle which does not close on itself
+func (e *Edge) IsArc() bool {
+	if !e.IsCircle() {
+		return false
+	}
+	return e.FirstVertex().Distance(e.LastVertex()) > gp.Resolution()
+}
+
+// IsParallelToSketch returns whether this edge keeps its shape when projected to the sketch.
+// Lines always do. Circles and arcs do when their axis is along the sketch normal.
+func (e *Edge) IsParallelToSketch(solver SketchSolver) bool {
+	if e.IsLine() {
+		return true
+	}
+	if !e.IsCircle() {
+		return false
+	}
+
+	return e.CircleAxis().IsParallel(solver.CoordinateSystem().Direction())
+}
+
+// CircleAxis returns the axis direction of this edge if it is a circle, pointing along the right hand rule from its
+// first vertex. Edges which are not circles return the Z direction.
+func (e *Edge) CircleAxis() gp.Dir {
+	if !e.IsCircle() {
+		return gp.NewDir(0, 0, 1)
+	}
+	arc := e.circularArc()
+	return gp.NewDirVec(arc.U.ToVector().Crossed(arc.V.ToVector()))
+}
+
 // CircleRadius returns the radius of this edge if it is a circle
 func (e *Edge) CircleRadius() float64 {
 	if !e.IsCircle() {
@@ -254,6 +403,107 @@ func (e *Edge) IsParallel(v gp.Dir) bool {
 	return dir.IsParallel(v)
 }
 
+// Center returns the center of mass of the edge
+func (e *Edge) Center() gp.Pnt {
+	props := gprop.NewGProps()
+	brepgprop.LinearProperties(topods.NewShapeFromRef(topods.TopoDSShape(e.Edge.Edge)), props, false, false)
+	return props.CenterOfMass()
+}
+
+// IsSame returns whether this edge and other are the same edge of a shape. An edge shared by two faces is found
+// once from each face with a different orientation, so edges are also the same when they join the same vertices
+// and have the same center.
+func (e *Edge) IsSame(other *Edge) bool {
+	if topods.NewShapeFromRef(topods.TopoDSShape(e.Edge.Edge)).IsEqual(topods.TopoDSShape(other.Edge.Edge)) {
+		return true
+	}
+	same := func(a, b gp.Pnt) bool { return utils.StandardFloatCompare(a.Distance(b), 0) == 0 }
+	first, last := e.FirstVertex(), e.LastVertex()
+	otherFirst, otherLast := other.FirstVertex(), other.LastVertex()
+	if !(same(first, otherFirst) && same(last, otherLast)) && !(same(first, otherLast) && same(last, otherFirst)) {
+		return false
+	}
+	return same(e.Center(), other.Center())
+}
+
+// CircularArc is a circular arc running from Center + Radius*U towards Center + Radius*V by Sweep radians.
+// U and V are perpendicular unit vectors.
+type CircularArc struct {
+	Center Vector
+	U      Vector
+	V      Vector
+	Radius float64
+	Sweep  float64
+}
+
+// At returns the point of the arc the angle in radians from its start
+func (a CircularArc) At(angle float64) Vector {
+	c, s := a.Radius*math.Cos(angle), a.Radius*math.Sin(angle)
+	return Vector{a.Center.X + c*a.U.X + s*a.V.X, a.Center.Y + c*a.U.Y + s*a.V.Y, a.Center.Z + c*a.U.Z + s*a.V.Z}
+}
+
+// CircularArc returns the arc of an edge which is a circle, starting at its first vertex. A closed circle sweeps 2π.
+func (e *Edge) CircularArc() (CircularArc, error) {
+	if !e.IsCircle() {
+		return CircularArc{}, errors.New("edge is not a circle or arc")
+	}
+	return e.circularArc(), nil
+}
+
+func (e *Edge) circularArc() CircularArc {
+	circle := brepadapter.NewCurve(e.Edge).ToCircle()
+	center := circle.Location()
+	u := gp.NewVecPoints(center, e.FirstVertex()).Normalized()
+	arc := CircularArc{Center: *NewVector(center), U: *NewVector(u), Radius: circle.Radius(), Sweep: 2 * math.Pi}
+	if e.IsArc() {
+		// The center of mass of an arc lies on the line from the center through the middle of the arc
+		arc.Sweep = e.LineLength() / arc.Radius
+		middle := gp.NewVecPoints(center, e.Center())
+		along := middle.Dot(u)
+		arc.V = *NewVector(gp.NewVec(middle.X()-along*u.X(), middle.Y()-along*u.Y(), middle.Z()-along*u.Z()).Normalized())
+	} else {
+		// A closed circle bounds a planar face whose normal is the circle's axis
+		wire := brepbuilderapi.NewMakeWireWithEdge(e.Edge).ToTopoDSWire()
+		face := brepbuilderapi.NewMakeFace(wire).ToTopoDSFace()
+		normal := brepadapter.NewSurface(face).Plane().Axis().Direction()
+		arc.V = *NewVector(gp.NewVecDir(normal).Crossed(u).Normalized())
+	}
+	return arc
+}
+
+// PointsAlong returns count points evenly spaced along a line, arc or circle edge from its first vertex.
+// The points of a closed circle are spread around it without repeating the first vertex. Other curves are not supported.
+func (e *Edge) PointsAlong(count int) ([]Vector, error) {
+	if count < 1 {
+		return nil, errors.New("count must be at least 1")
+	}
+	steps := float64(max(count-1, 1))
+	points := make([]Vector, 0, count)
+	switch {
+	case e.IsLine():
+		first, last := e.FirstVertex(), e.LastVertex()
+		for i := range count {
+			t := float64(i) / steps
+			points = append(points, Vector{
+				first.X() + (last.X()-first.X())*t,
+				first.Y() + (last.Y()-first.Y())*t,
+				first.Z() + (last.Z()-first.Z())*t,
+			})
+		}
+	case e.IsCircle():
+		arc := e.circularArc()
+		if !e.IsArc() {
+			steps = float64(count)
+		}
+		for i := range count {
+			points = append(points, arc.At(arc.Sweep*float64(i)/steps))
+		}
+	default:
+		return nil, errors.New("points can only be spaced along lines, arcs and circles")
+	}
+	return points, nil
+}
+
 // Midpoint returns the midpoint of a line (or origin if it is not a line)
 func (e *Edge) Midpoint() gp.Pnt {
 	if !e.IsLine() {
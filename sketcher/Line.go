@@ -2,6 +2,7 @@ package sketcher
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/marcuswu/gooccwrapper/brepbuilderapi"
 	"github.com/marcuswu/gooccwrapper/geom"
@@ -71,6 +72,47 @@ func (l *Line) Angle(other *Line, angle float64) *Line {
 	return l
 }
 
+// Mirrored creates a copy of this line mirrored across axis with its endpoints constrained symmetric to this line's endpoints
+func (l *Line) Mirrored(axis *Line) *Line {
+	return l.mirrored(&pointMirrors{axis: axis})
+}
+
+func (l *Line) mirrored(mirrors *pointMirrors) *Line {
+	startX, startY, _ := mirrors.axis.reflect(l.Start.X, l.Start.Y)
+	endX, endY, _ := mirrors.axis.reflect(l.End.X, l.End.Y)
+	mirrored := l.solver.CreateLine(startX, startY, endX, endY)
+	mirrored.SetConstruction(l.isConstruction)
+	mirrors.constrain(l.Start, mirrored.Start)
+	mirrors.constrain(l.End, mirrored.End)
+
+	return mirrored
+}
+
+// endpoints returns the coordinates of two points on the line. The sketch axes have no start or end so a unit segment from the origin is used.
+func (l *Line) endpoints() (float64, float64, float64, float64) {
+	if l.Start != nil && l.End != nil {
+		return l.Start.X, l.Start.Y, l.End.X, l.End.Y
+	}
+	if l == l.solver.YAxis() {
+		return 0, 0, 0, 1
+	}
+	return 0, 0, 1, 0
+}
+
+// reflect returns the reflection of (x, y) across this line along with the distance of (x, y) from the line
+func (l *Line) reflect(x float64, y float64) (float64, float64, float64) {
+	x1, y1, x2, y2 := l.endpoints()
+	dx, dy := x2-x1, y2-y1
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		return x, y, 0
+	}
+	dx, dy = dx/length, dy/length
+	along := (x-x1)*dx + (y-y1)*dy
+	projX, projY := x1+dx*along, y1+dy*along
+	return 2*projX - x, 2*projY - y, math.Hypot(x-projX, y-projY)
+}
+
 // MakeEdge generates an edge from the sketch element. Usually this is handled by MakerCad.
 func (l *Line) MakeEdge() *Edge {
 	if l.Start.ID() == l.End.ID() {
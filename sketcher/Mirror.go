@@ -0,0 +1,55 @@
+package sketcher
+
+// pointMirrors records the mirror image of each point mirrored across an axis so that a point shared by connected
+// entities is only constrained symmetric once
+type pointMirrors struct {
+	axis      *Line
+	originals []*Point
+	images    []*Point
+}
+
+// find returns the mirror image of a point at the same location as original, or nil if there is none yet
+func (m *pointMirrors) find(original *Point) *Point {
+	for i, o := range m.originals {
+		if o == original || o.IsConnectedTo(original) {
+			return m.images[i]
+		}
+	}
+	return nil
+}
+
+// constrain makes image the mirror of original. When a point at the same location has been mirrored already, image is
+// made coincident with that mirror instead of adding another symmetry constraint.
+func (m *pointMirrors) constrain(original *Point, image *Point) {
+	if existing := m.find(original); existing != nil {
+		image.Coincident(existing)
+		return
+	}
+	original.symmetricTo(image, m.axis)
+	m.originals = append(m.originals, original)
+	m.images = append(m.images, image)
+}
+
+// MirrorEntities creates mirrored copies of the entities across axis. Each copy is constrained symmetric to its
+// original. Endpoints shared by connected entities are mirrored once and the copies are joined at that mirror image.
+// The axis itself is not mirrored.
+func MirrorEntities(entities []Entity, axis *Line) []Entity {
+	mirrors := &pointMirrors{axis: axis}
+	mirrored := make([]Entity, 0, len(entities))
+	for _, entity := range entities {
+		switch e := entity.(type) {
+		case *Point:
+			mirrored = append(mirrored, e.mirrored(mirrors))
+		case *Line:
+			if e == axis {
+				continue
+			}
+			mirrored = append(mirrored, e.mirrored(mirrors))
+		case *Arc:
+			mirrored = append(mirrored, e.mirrored(mirrors))
+		case *Circle:
+			mirrored = append(mirrored, e.mirrored(mirrors))
+		}
+	}
+	return mirrored
+}
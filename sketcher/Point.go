@@ -91,6 +91,40 @@ func (p *Point) VerticalDistance(other Entity, distance float64) *Point {
 	return p
 }
 
+// Symmetric creates a constraint placing this point as the mirror image of the provided point across axis
+func (p *Point) Symmetric(other *Point, axis *Line) *Point {
+	p.solver.PointSymmetric(p, other, axis)
+
+	return p
+}
+
+// Mirrored creates a copy of this point mirrored across axis and constrained symmetric to this point
+func (p *Point) Mirrored(axis *Line) *Point {
+	return p.mirrored(&pointMirrors{axis: axis})
+}
+
+// mirrored returns the existing mirror image of a point at this location, or creates one
+func (p *Point) mirrored(mirrors *pointMirrors) *Point {
+	if existing := mirrors.find(p); existing != nil {
+		return existing
+	}
+	x, y, _ := mirrors.axis.reflect(p.X, p.Y)
+	mirrored := p.solver.CreatePoint(x, y)
+	mirrored.SetConstruction(p.isConstruction)
+	mirrors.constrain(p, mirrored)
+
+	return mirrored
+}
+
+// symmetricTo constrains other to mirror this point across axis. Points lying on the axis are their own mirror image, so they are made coincident instead.
+func (p *Point) symmetricTo(other *Point, axis *Line) {
+	if _, _, dist := axis.reflect(p.X, p.Y); utils.StandardFloatCompare(dist, 0) == 0 {
+		other.Coincident(p)
+		return
+	}
+	p.Symmetric(other, axis)
+}
+
 func (p *Point) String() string {
 	return fmt.Sprintf("(%f, %f)", p.X, p.Y)
 }
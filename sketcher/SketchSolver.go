@@ -26,6 +26,7 @@ type SketchSolver interface {
 	HorizontalPoints(*Point, *Point)
 	VerticalLine(*Line)
 	VerticalPoints(*Point, *Point)
+	PointSymmetric(*Point, *Point, *Line)
 	LineLength(*Line, float64)
 	Equal(Entity, Entity)
 	CurveDiameter(Entity, float64)
@@ -33,6 +34,7 @@ type SketchSolver interface {
 	Transform() gp.Trsf
 	Solve() error
 	OverConstrained() []string
+	Constraints() []*Constraint
 	Entities() []Entity
 	LogDebug(string) error
 	ExportImage(string, ...float64) error
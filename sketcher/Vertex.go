@@ -3,6 +3,8 @@ package sketcher
 import (
 	"slices"
 
+	"github.com/marcuswu/dlineate/utils"
+
 	"github.com/marcuswu/gooccwrapper/gp"
 	"github.com/marcuswu/gooccwrapper/topods"
 )
@@ -43,6 +45,11 @@ func (l ListOfVertex) Sort(sorter VertexSorter) {
 	slices.SortFunc(l, sorter)
 }
 
+// IsSame returns whether this vertex and other are at the same position
+func (v *Vertex) IsSame(other *Vertex) bool {
+	return utils.StandardFloatCompare(v.ToPoint().Distance(other.ToPoint()), 0) == 0
+}
+
 func (v Vertex) ToPoint() gp.Pnt {
 	return v.Vertex.Pnt()
 }
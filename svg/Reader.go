@@ -0,0 +1,527 @@
+package svg
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Read returns the outlines of the path, rect, circle, ellipse, line, polyline and polygon elements of an SVG document
+// with their transforms applied. Coordinates are SVG user units. Elements inside definitions such as defs and clipPath are ignored.
+func Read(reader io.Reader) ([]Path, error) {
+	decoder := xml.NewDecoder(reader)
+	transforms := []Transform{Identity}
+	skipDepth := 0
+	paths := make([]Path, 0)
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return paths, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if skipDepth > 0 || isDefinition(t.Name.Local) {
+				skipDepth++
+				continue
+			}
+			attrs := make(map[string]string, len(t.Attr))
+			for _, attr := range t.Attr {
+				attrs[attr.Name.Local] = attr.Value
+			}
+			local, err := parseTransform(attrs["transform"])
+			if err != nil {
+				return nil, err
+			}
+			transform := transforms[len(transforms)-1].Multiply(local)
+			transforms = append(transforms, transform)
+
+			elementPaths, err := elementPaths(t.Name.Local, attrs)
+			if err != nil {
+				return nil, fmt.Errorf("%s element: %w", t.Name.Local, err)
+			}
+			for i := range elementPaths {
+				elementPaths[i].transform(transform)
+			}
+			paths = append(paths, elementPaths...)
+		case xml.EndElement:
+			if skipDepth > 0 {
+				skipDepth--
+				continue
+			}
+			transforms = transforms[:len(transforms)-1]
+		}
+	}
+}
+
+func isDefinition(name string) bool {
+	switch name {
+	case "defs", "clipPath", "mask", "marker", "pattern", "symbol":
+		return true
+	}
+	return false
+}
+
+// elementPaths converts a shape element to paths in its own coordinate system
+func elementPaths(name string, attrs map[string]string) ([]Path, error) {
+	number := func(key string) float64 {
+		v, _ := strconv.ParseFloat(strings.TrimSpace(attrs[key]), 64)
+		return v
+	}
+
+	switch name {
+	case "path":
+		return ParsePath(attrs["d"])
+	case "line":
+		from, to := Point{number("x1"), number("y1")}, Point{number("x2"), number("y2")}
+		return []Path{{Segments: []Segment{&Line{from, to}}}}, nil
+	case "polyline", "polygon":
+		values, err := parseNumbers(attrs["points"])
+		if err != nil {
+			return nil, err
+		}
+		points := make([]Point, 0, len(values)/2)
+		for i := 0; i+1 < len(values); i += 2 {
+			points = append(points, Point{values[i], values[i+1]})
+		}
+		return []Path{polygonPath(points, name == "polygon")}, nil
+	case "rect":
+		return []Path{rectPath(number("x"), number("y"), number("width"), number("height"), attrs)}, nil
+	case "circle":
+		center, r := Point{number("cx"), number("cy")}, number("r")
+		if r <= 0 {
+			return nil, nil
+		}
+		right, left := Point{center.X + r, center.Y}, Point{center.X - r, center.Y}
+		return []Path{{Segments: []Segment{
+			&Arc{From: right, To: left, Center: center, Radius: r, Sweep: true},
+			&Arc{From: left, To: right, Center: center, Radius: r, Sweep: true},
+		}, Closed: true}}, nil
+	case "ellipse":
+		center, rx, ry := Point{number("cx"), number("cy")}, number("rx"), number("ry")
+		if rx <= 0 || ry <= 0 {
+			return nil, nil
+		}
+		start := Point{center.X + rx, center.Y}
+		segments := make([]Segment, 0, 4)
+		for _, cubic := range ellipseCubics(start, start, center, rx, ry, 0, 0, 2*math.Pi) {
+			segments = append(segments, cubic)
+		}
+		return []Path{{Segments: segments, Closed: true}}, nil
+	}
+	return nil, nil
+}
+
+func polygonPath(points []Point, closed bool) Path {
+	path := Path{Segments: make([]Segment, 0, len(points)), Closed: closed}
+	for i := 1; i < len(points); i++ {
+		path.Segments = append(path.Segments, &Line{points[i-1], points[i]})
+	}
+	if closed && len(points) > 2 && points[0] != points[len(points)-1] {
+		path.Segments = append(path.Segments, &Line{points[len(points)-1], points[0]})
+	}
+	return path
+}
+
+// rectPath creates the outline of a rectangle, rounding its corners when rx or ry are set
+func rectPath(x, y, width, height float64, attrs map[string]string) Path {
+	rx, rxErr := strconv.ParseFloat(strings.TrimSpace(attrs["rx"]), 64)
+	ry, ryErr := strconv.ParseFloat(strings.TrimSpace(attrs["ry"]), 64)
+	if rxErr != nil {
+		rx = ry
+	}
+	if ryErr != nil {
+		ry = rx
+	}
+	rx = math.Max(0, math.Min(rx, width/2))
+	ry = math.Max(0, math.Min(ry, height/2))
+	if rx == 0 || ry == 0 {
+		return polygonPath([]Point{{x, y}, {x + width, y}, {x + width, y + height}, {x, y + height}}, true)
+	}
+
+	path := Path{Segments: make([]Segment, 0, 8), Closed: true}
+	corner := func(from Point, to Point, center Point, startAngle float64) {
+		if rx == ry {
+			path.Segments = append(path.Segments, &Arc{From: from, To: to, Center: center, Radius: rx, Sweep: true})
+			return
+		}
+		for _, cubic := range ellipseCubics(from, to, center, rx, ry, 0, startAngle, math.Pi/2) {
+			path.Segments = append(path.Segments, cubic)
+		}
+	}
+	path.Segments = append(path.Segments, &Line{Point{x + rx, y}, Point{x + width - rx, y}})
+	corner(Point{x + width - rx, y}, Point{x + width, y + ry}, Point{x + width - rx, y + ry}, -math.Pi/2)
+	path.Segments = append(path.Segments, &Line{Point{x + width, y + ry}, Point{x + width, y + height - ry}})
+	corner(Point{x + width, y + height - ry}, Point{x + width - rx, y + height}, Point{x + width - rx, y + height - ry}, 0)
+	path.Segments = append(path.Segments, &Line{Point{x + width - rx, y + height}, Point{x + rx, y + height}})
+	corner(Point{x + rx, y + height}, Point{x, y + height - ry}, Point{x + rx, y + height - ry}, math.Pi/2)
+	path.Segments = append(path.Segments, &Line{Point{x, y + height - ry}, Point{x, y + ry}})
+	corner(Point{x, y + ry}, Point{x + rx, y}, Point{x + rx, y + ry}, math.Pi)
+	return path
+}
+
+var transformPattern = regexp.MustCompile(`(\w+)\s*\(([^)]*)\)`)
+
+// parseTransform parses the value of a transform attribute
+func parseTransform(value string) (Transform, error) {
+	transform := Identity
+	for _, match := range transformPattern.FindAllStringSubmatch(value, -1) {
+		args, err := parseNumbers(match[2])
+		if err != nil {
+			return Identity, err
+		}
+		arg := func(i int, fallback float64) float64 {
+			if i < len(args) {
+				return args[i]
+			}
+			return fallback
+		}
+
+		var t Transform
+		switch match[1] {
+		case "matrix":
+			if len(args) != 6 {
+				return Identity, errors.New("matrix transform requires 6 values")
+			}
+			t = Transform(args)
+		case "translate":
+			t = Transform{1, 0, 0, 1, arg(0, 0), arg(1, 0)}
+		case "scale":
+			t = Transform{arg(0, 1), 0, 0, arg(1, arg(0, 1)), 0, 0}
+		case "rotate":
+			angle := arg(0, 0) * math.Pi / 180
+			cx, cy := arg(1, 0), arg(2, 0)
+			rotation := Transform{math.Cos(angle), math.Sin(angle), -math.Sin(angle), math.Cos(angle), 0, 0}
+			t = Transform{1, 0, 0, 1, cx, cy}.Multiply(rotation).Multiply(Transform{1, 0, 0, 1, -cx, -cy})
+		case "skewX":
+			t = Transform{1, 0, math.Tan(arg(0, 0) * math.Pi / 180), 1, 0, 0}
+		case "skewY":
+			t = Transform{1, math.Tan(arg(0, 0) * math.Pi / 180), 0, 1, 0, 0}
+		default:
+			return Identity, fmt.Errorf("unknown transform %q", match[1])
+		}
+		transform = transform.Multiply(t)
+	}
+	return transform, nil
+}
+
+func parseNumbers(value string) ([]float64, error) {
+	scanner := &pathScanner{s: value}
+	numbers := make([]float64, 0)
+	for scanner.hasNumber() {
+		n, err := scanner.number()
+		if err != nil {
+			return nil, err
+		}
+		numbers = append(numbers, n)
+	}
+	scanner.skipSeparators()
+	if scanner.i < len(scanner.s) {
+		return nil, fmt.Errorf("unexpected %q in number list", scanner.s[scanner.i:])
+	}
+	return numbers, nil
+}
+
+// pathScanner reads the commands and numbers of SVG path data
+type pathScanner struct {
+	s string
+	i int
+}
+
+func (p *pathScanner) skipSeparators() {
+	for p.i < len(p.s) && strings.ContainsRune(" \t\r\n,", rune(p.s[p.i])) {
+		p.i++
+	}
+}
+
+func (p *pathScanner) hasNumber() bool {
+	p.skipSeparators()
+	return p.i < len(p.s) && strings.ContainsRune("+-.0123456789", rune(p.s[p.i]))
+}
+
+func (p *pathScanner) number() (float64, error) {
+	p.skipSeparators()
+	start := p.i
+	if p.i < len(p.s) && (p.s[p.i] == '+' || p.s[p.i] == '-') {
+		p.i++
+	}
+	seenDot := false
+	for p.i < len(p.s) {
+		c := p.s[p.i]
+		if c >= '0' && c <= '9' {
+			p.i++
+		} else if c == '.' && !seenDot {
+			seenDot = true
+			p.i++
+		} else {
+			break
+		}
+	}
+	if p.i < len(p.s) && (p.s[p.i] == 'e' || p.s[p.i] == 'E') {
+		p.i++
+		if p.i < len(p.s) && (p.s[p.i] == '+' || p.s[p.i] == '-') {
+			p.i++
+		}
+		for p.i < len(p.s) && p.s[p.i] >= '0' && p.s[p.i] <= '9' {
+			p.i++
+		}
+	}
+	n, err := strconv.ParseFloat(p.s[start:p.i], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q at offset %d", p.s[start:p.i], start)
+	}
+	return n, nil
+}
+
+// flag reads an arc flag, which may be written without a separator before the next value
+func (p *pathScanner) flag() (bool, error) {
+	p.skipSeparators()
+	if p.i < len(p.s) && (p.s[p.i] == '0' || p.s[p.i] == '1') {
+		p.i++
+		return p.s[p.i-1] == '1', nil
+	}
+	return false, fmt.Errorf("invalid arc flag at offset %d", p.i)
+}
+
+func (p *pathScanner) numbers(count int) ([]float64, error) {
+	values := make([]float64, count)
+	for i := range values {
+		v, err := p.number()
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// ParsePath converts SVG path data into paths, one for each subpath
+func ParsePath(d string) ([]Path, error) {
+	scanner := &pathScanner{s: d}
+	paths := make([]Path, 0)
+	var path *Path
+	var current, subpathStart, lastControl Point
+	var command, lastCommand byte
+
+	finish := func() {
+		if path != nil && len(path.Segments) > 0 {
+			paths = append(paths, *path)
+		}
+		path = nil
+	}
+	add := func(segment Segment) {
+		if path == nil {
+			path = &Path{}
+			subpathStart = current
+		}
+		path.Segments = append(path.Segments, segment)
+		current = segment.End()
+	}
+
+	for {
+		scanner.skipSeparators()
+		if scanner.i >= len(scanner.s) {
+			break
+		}
+		if c := scanner.s[scanner.i]; strings.IndexByte("MmLlHhVvCcSsQqTtAaZz", c) >= 0 {
+			command = c
+			scanner.i++
+		} else if command == 0 || !scanner.hasNumber() {
+			return nil, fmt.Errorf("unexpected %q at offset %d", scanner.s[scanner.i], scanner.i)
+		}
+
+		relative := command >= 'a' && command <= 'z'
+		offset := func(x, y float64) Point {
+			if relative {
+				return Point{current.X + x, current.Y + y}
+			}
+			return Point{x, y}
+		}
+		smoothControl := func(previous string) Point {
+			if strings.IndexByte(previous, lastCommand) >= 0 {
+				return Point{2*current.X - lastControl.X, 2*current.Y - lastControl.Y}
+			}
+			return current
+		}
+
+		switch command {
+		case 'M', 'm':
+			v, err := scanner.numbers(2)
+			if err != nil {
+				return nil, err
+			}
+			finish()
+			current = offset(v[0], v[1])
+			subpathStart = current
+			// Further coordinate pairs are implicit line commands
+			command = 'L'
+			if relative {
+				command = 'l'
+			}
+		case 'L', 'l':
+			v, err := scanner.numbers(2)
+			if err != nil {
+				return nil, err
+			}
+			add(&Line{current, offset(v[0], v[1])})
+		case 'H', 'h':
+			v, err := scanner.numbers(1)
+			if err != nil {
+				return nil, err
+			}
+			x := v[0]
+			if relative {
+				x += current.X
+			}
+			add(&Line{current, Point{x, current.Y}})
+		case 'V', 'v':
+			v, err := scanner.numbers(1)
+			if err != nil {
+				return nil, err
+			}
+			y := v[0]
+			if relative {
+				y += current.Y
+			}
+			add(&Line{current, Point{current.X, y}})
+		case 'C', 'c':
+			v, err := scanner.numbers(6)
+			if err != nil {
+				return nil, err
+			}
+			cubic := &Cubic{current, offset(v[0], v[1]), offset(v[2], v[3]), offset(v[4], v[5])}
+			lastControl = cubic.Control2
+			add(cubic)
+		case 'S', 's':
+			v, err := scanner.numbers(4)
+			if err != nil {
+				return nil, err
+			}
+			cubic := &Cubic{current, smoothControl("CcSs"), offset(v[0], v[1]), offset(v[2], v[3])}
+			lastControl = cubic.Control2
+			add(cubic)
+		case 'Q', 'q':
+			v, err := scanner.numbers(4)
+			if err != nil {
+				return nil, err
+			}
+			control := offset(v[0], v[1])
+			lastControl = control
+			add(quadraticCubic(current, control, offset(v[2], v[3])))
+		case 'T', 't':
+			v, err := scanner.numbers(2)
+			if err != nil {
+				return nil, err
+			}
+			control := smoothControl("QqTt")
+			lastControl = control
+			add(quadraticCubic(current, control, offset(v[0], v[1])))
+		case 'A', 'a':
+			radii, err := scanner.numbers(3)
+			if err != nil {
+				return nil, err
+			}
+			largeArc, err := scanner.flag()
+			if err != nil {
+				return nil, err
+			}
+			sweep, err := scanner.flag()
+			if err != nil {
+				return nil, err
+			}
+			v, err := scanner.numbers(2)
+			if err != nil {
+				return nil, err
+			}
+			for _, segment := range arcSegments(current, offset(v[0], v[1]), radii[0], radii[1], radii[2], largeArc, sweep) {
+				add(segment)
+			}
+		case 'Z', 'z':
+			if path != nil {
+				if current != subpathStart {
+					add(&Line{current, subpathStart})
+				}
+				path.Closed = true
+			}
+			current = subpathStart
+			finish()
+		}
+		lastCommand = command
+	}
+	finish()
+
+	return paths, nil
+}
+
+// quadraticCubic converts a quadratic Bézier curve to the equivalent cubic
+func quadraticCubic(from, control, to Point) *Cubic {
+	return &Cubic{
+		From:     from,
+		Control1: Point{from.X + 2.0/3.0*(control.X-from.X), from.Y + 2.0/3.0*(control.Y-from.Y)},
+		Control2: Point{to.X + 2.0/3.0*(control.X-to.X), to.Y + 2.0/3.0*(control.Y-to.Y)},
+		To:       to,
+	}
+}
+
+// arcSegments converts an SVG endpoint arc to a circular arc or, for elliptical arcs, cubic curves
+func arcSegments(from, to Point, rx, ry, rotation float64, largeArc, sweep bool) []Segment {
+	if from == to {
+		return nil
+	}
+	rx, ry = math.Abs(rx), math.Abs(ry)
+	if rx == 0 || ry == 0 {
+		return []Segment{&Line{from, to}}
+	}
+
+	// Convert to center parameterization as described in the SVG implementation notes
+	phi := rotation * math.Pi / 180
+	cosPhi, sinPhi := math.Cos(phi), math.Sin(phi)
+	dx, dy := (from.X-to.X)/2, (from.Y-to.Y)/2
+	x1 := cosPhi*dx + sinPhi*dy
+	y1 := -sinPhi*dx + cosPhi*dy
+
+	// Scale up radii which are too small to reach the endpoint
+	if lambda := x1*x1/(rx*rx) + y1*y1/(ry*ry); lambda > 1 {
+		rx, ry = rx*math.Sqrt(lambda), ry*math.Sqrt(lambda)
+	}
+	numerator := rx*rx*ry*ry - rx*rx*y1*y1 - ry*ry*x1*x1
+	denominator := rx*rx*y1*y1 + ry*ry*x1*x1
+	coefficient := math.Sqrt(math.Max(0, numerator/denominator))
+	if largeArc == sweep {
+		coefficient = -coefficient
+	}
+	cx1 := coefficient * rx * y1 / ry
+	cy1 := coefficient * -ry * x1 / rx
+	center := Point{
+		cosPhi*cx1 - sinPhi*cy1 + (from.X+to.X)/2,
+		sinPhi*cx1 + cosPhi*cy1 + (from.Y+to.Y)/2,
+	}
+
+	startAngle := math.Atan2((y1-cy1)/ry, (x1-cx1)/rx)
+	endAngle := math.Atan2((-y1-cy1)/ry, (-x1-cx1)/rx)
+	arcSweep := endAngle - startAngle
+	if sweep && arcSweep < 0 {
+		arcSweep += 2 * math.Pi
+	} else if !sweep && arcSweep > 0 {
+		arcSweep -= 2 * math.Pi
+	}
+
+	if math.Abs(rx-ry) < 1e-9*math.Max(rx, ry) {
+		return []Segment{&Arc{From: from, To: to, Center: center, Radius: rx, Sweep: sweep}}
+	}
+	cubics := ellipseCubics(from, to, center, rx, ry, phi, startAngle, arcSweep)
+	segments := make([]Segment, 0, len(cubics))
+	for _, cubic := range cubics {
+		segments = append(segments, cubic)
+	}
+	return segments
+}
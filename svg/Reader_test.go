@@ -0,0 +1,163 @@
+package svg
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		name string
+		d    string
+		want []Path
+	}{
+		{
+			name: "closed triangle",
+			d:    "M0 0 L10 0 L10 10 Z",
+			want: []Path{{Segments: []Segment{&Line{Point{0, 0}, Point{10, 0}}, &Line{Point{10, 0}, Point{10, 10}}, &Line{Point{10, 10}, Point{0, 0}}}, Closed: true}},
+		},
+		{
+			name: "open relative lines",
+			d:    "m1,1 h4 v2",
+			want: []Path{{Segments: []Segment{&Line{Point{1, 1}, Point{5, 1}}, &Line{Point{5, 1}, Point{5, 3}}}}},
+		},
+		{
+			name: "implicit line commands",
+			d:    "M0 0 5 0 5 5z",
+			want: []Path{{Segments: []Segment{&Line{Point{0, 0}, Point{5, 0}}, &Line{Point{5, 0}, Point{5, 5}}, &Line{Point{5, 5}, Point{0, 0}}}, Closed: true}},
+		},
+		{
+			name: "two subpaths",
+			d:    "M0 0 L1 0 M5 5 L6 5 L5 5 Z",
+			want: []Path{
+				{Segments: []Segment{&Line{Point{0, 0}, Point{1, 0}}}},
+				{Segments: []Segment{&Line{Point{5, 5}, Point{6, 5}}, &Line{Point{6, 5}, Point{5, 5}}}, Closed: true},
+			},
+		},
+		{
+			name: "quadratic curve",
+			d:    "M0 0 Q3 3 6 0",
+			want: []Path{{Segments: []Segment{&Cubic{Point{0, 0}, Point{2, 2}, Point{4, 2}, Point{6, 0}}}}},
+		},
+		{
+			name: "circular arc",
+			d:    "M0 0 A5 5 0 0 1 10 0",
+			want: []Path{{Segments: []Segment{&Arc{From: Point{0, 0}, To: Point{10, 0}, Center: Point{5, 0}, Radius: 5, Sweep: true}}}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			paths, err := ParsePath(test.d)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(paths, test.want) {
+				t.Errorf("got %s, want %s", describe(paths), describe(test.want))
+			}
+		})
+	}
+}
+
+func TestParsePathErrors(t *testing.T) {
+	for _, d := range []string{"M0 0 L1", "M0 0 X4 4", "M0 0 A5 5 0 2 1 10 0"} {
+		t.Run(d, func(t *testing.T) {
+			if _, err := ParsePath(d); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestPathIsClosed(t *testing.T) {
+	tests := []struct {
+		name string
+		path Path
+		want bool
+	}{
+		{"empty", Path{}, false},
+		{"closed", Path{Segments: []Segment{&Line{Point{0, 0}, Point{1, 0}}}, Closed: true}, true},
+		{"open", Path{Segments: []Segment{&Line{Point{0, 0}, Point{1, 0}}, &Line{Point{1, 0}, Point{1, 1}}}}, false},
+		{"drawn back to its start", Path{Segments: []Segment{&Line{Point{0, 0}, Point{1, 0}}, &Line{Point{1, 0}, Point{0, 0}}}}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.path.IsClosed(); got != test.want {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		closed   []bool
+		first    Point
+	}{
+		{"rect", `<svg><rect x="1" y="2" width="3" height="4"/></svg>`, []bool{true}, Point{1, 2}},
+		{"translated group", `<svg><g transform="translate(10 20)"><polygon points="0,0 1,0 1,1"/></g></svg>`, []bool{true}, Point{10, 20}},
+		{"scaled line", `<svg><line transform="scale(2)" x1="1" y1="1" x2="3" y2="1"/></svg>`, []bool{false}, Point{2, 2}},
+		{"definitions are ignored", `<svg><defs><circle r="4"/></defs><polyline points="0,0 1,1"/></svg>`, []bool{false}, Point{0, 0}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			paths, err := Read(strings.NewReader(test.document))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(paths) != len(test.closed) {
+				t.Fatalf("got %d paths, want %d", len(paths), len(test.closed))
+			}
+			for i, path := range paths {
+				if path.IsClosed() != test.closed[i] {
+					t.Errorf("path %d closed is %v, want %v", i, path.IsClosed(), test.closed[i])
+				}
+			}
+			if first := paths[0].Segments[0].Start(); math.Abs(first.X-test.first.X) > 1e-9 || math.Abs(first.Y-test.first.Y) > 1e-9 {
+				t.Errorf("path starts at %v, want %v", first, test.first)
+			}
+		})
+	}
+}
+
+func TestReadCircleTransform(t *testing.T) {
+	tests := []struct {
+		name      string
+		transform string
+		arcs      bool
+	}{
+		{"uniform scale keeps arcs", "scale(2)", true},
+		{"reflection keeps arcs", "scale(-1 1)", true},
+		{"non uniform scale makes cubics", "scale(2 1)", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			paths, err := Read(strings.NewReader(`<svg><circle transform="` + test.transform + `" cx="0" cy="0" r="1"/></svg>`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, segment := range paths[0].Segments {
+				if _, ok := segment.(*Arc); ok != test.arcs {
+					t.Errorf("segment %T, want arcs %v", segment, test.arcs)
+				}
+			}
+		})
+	}
+}
+
+// describe formats paths with their segment values rather than pointers
+func describe(paths []Path) string {
+	parts := make([]string, 0, len(paths))
+	for _, path := range paths {
+		segments := make([]string, 0, len(path.Segments))
+		for _, segment := range path.Segments {
+			segments = append(segments, fmt.Sprintf("%v", segment))
+		}
+		parts = append(parts, fmt.Sprintf("%s closed %v", strings.Join(segments, " "), path.Closed))
+	}
+	return strings.Join(parts, ", ")
+}
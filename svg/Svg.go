@@ -0,0 +1,169 @@
+// Package svg reads the outlines of SVG documents and writes SVG drawings of sketches
+package svg
+
+import (
+	"math"
+)
+
+// Point is a 2D coordinate in SVG user units. Y increases downwards.
+type Point struct {
+	X float64
+	Y float64
+}
+
+// Segment is a piece of a [Path]: a [Line], an [Arc] or a [Cubic]
+type Segment interface {
+	Start() Point
+	End() Point
+}
+
+// Line is a straight path segment
+type Line struct {
+	From Point
+	To   Point
+}
+
+// Arc is a circular path segment. Sweep is true when the arc runs in the direction of increasing angle, which is clockwise as displayed.
+type Arc struct {
+	From   Point
+	To     Point
+	Center Point
+	Radius float64
+	Sweep  bool
+}
+
+// Cubic is a cubic Bézier path segment. Quadratic curves and elliptical arcs are converted to cubics.
+type Cubic struct {
+	From     Point
+	Control1 Point
+	Control2 Point
+	To       Point
+}
+
+func (l *Line) Start() Point  { return l.From }
+func (l *Line) End() Point    { return l.To }
+func (a *Arc) Start() Point   { return a.From }
+func (a *Arc) End() Point     { return a.To }
+func (c *Cubic) Start() Point { return c.From }
+func (c *Cubic) End() Point   { return c.To }
+
+// Path is a connected sequence of segments. Closed paths end where they start.
+type Path struct {
+	Segments []Segment
+	Closed   bool
+}
+
+// IsClosed returns whether the path ends where it starts, either because it was closed or because it was drawn back to its start
+func (p Path) IsClosed() bool {
+	if p.Closed {
+		return true
+	}
+	return len(p.Segments) > 0 && p.Segments[0].Start() == p.Segments[len(p.Segments)-1].End()
+}
+
+// Transform is an affine transform stored as the SVG matrix(a b c d e f)
+type Transform [6]float64
+
+// Identity is the transform which leaves points unchanged
+var Identity = Transform{1, 0, 0, 1, 0, 0}
+
+// Multiply returns the transform applying o first and then t
+func (t Transform) Multiply(o Transform) Transform {
+	return Transform{
+		t[0]*o[0] + t[2]*o[1],
+		t[1]*o[0] + t[3]*o[1],
+		t[0]*o[2] + t[2]*o[3],
+		t[1]*o[2] + t[3]*o[3],
+		t[0]*o[4] + t[2]*o[5] + t[4],
+		t[1]*o[4] + t[3]*o[5] + t[5],
+	}
+}
+
+// Apply transforms a point
+func (t Transform) Apply(p Point) Point {
+	return Point{t[0]*p.X + t[2]*p.Y + t[4], t[1]*p.X + t[3]*p.Y + t[5]}
+}
+
+func (t Transform) determinant() float64 {
+	return t[0]*t[3] - t[1]*t[2]
+}
+
+// isSimilarity returns whether the transform keeps circles circular (rotation, translation, reflection and uniform scale)
+func (t Transform) isSimilarity() bool {
+	const tolerance = 1e-9
+	columnsOrthogonal := math.Abs(t[0]*t[2]+t[1]*t[3]) < tolerance
+	columnsEqual := math.Abs(math.Hypot(t[0], t[1])-math.Hypot(t[2], t[3])) < tolerance
+	return columnsOrthogonal && columnsEqual
+}
+
+// transform applies t to every segment. Arcs stay arcs when t keeps circles circular and become cubics otherwise.
+func (p *Path) transform(t Transform) {
+	reflects := t.determinant() < 0
+	similarity := t.isSimilarity()
+	segments := make([]Segment, 0, len(p.Segments))
+	for _, segment := range p.Segments {
+		switch s := segment.(type) {
+		case *Line:
+			segments = append(segments, &Line{t.Apply(s.From), t.Apply(s.To)})
+		case *Arc:
+			if !similarity {
+				for _, cubic := range s.cubics() {
+					segments = append(segments, &Cubic{t.Apply(cubic.From), t.Apply(cubic.Control1), t.Apply(cubic.Control2), t.Apply(cubic.To)})
+				}
+				continue
+			}
+			segments = append(segments, &Arc{
+				From:   t.Apply(s.From),
+				To:     t.Apply(s.To),
+				Center: t.Apply(s.Center),
+				Radius: s.Radius * math.Sqrt(math.Abs(t.determinant())),
+				Sweep:  s.Sweep != reflects,
+			})
+		case *Cubic:
+			segments = append(segments, &Cubic{t.Apply(s.From), t.Apply(s.Control1), t.Apply(s.Control2), t.Apply(s.To)})
+		}
+	}
+	p.Segments = segments
+}
+
+// cubics approximates the arc with cubic Bézier curves
+func (a *Arc) cubics() []*Cubic {
+	startAngle := math.Atan2(a.From.Y-a.Center.Y, a.From.X-a.Center.X)
+	endAngle := math.Atan2(a.To.Y-a.Center.Y, a.To.X-a.Center.X)
+	sweep := math.Mod(endAngle-startAngle+4*math.Pi, 2*math.Pi)
+	if !a.Sweep {
+		sweep -= 2 * math.Pi
+	}
+	return ellipseCubics(a.From, a.To, a.Center, a.Radius, a.Radius, 0, startAngle, sweep)
+}
+
+// ellipseCubics approximates an elliptical arc with one cubic Bézier curve per quarter turn or less.
+// The ellipse is rotated by phi radians and the arc runs sweep radians from startAngle.
+func ellipseCubics(from Point, to Point, center Point, rx float64, ry float64, phi float64, startAngle float64, sweep float64) []*Cubic {
+	count := max(1, int(math.Ceil(math.Abs(sweep)/(math.Pi/2)-1e-9)))
+	step := sweep / float64(count)
+	k := 4.0 / 3.0 * math.Tan(step/4)
+	onEllipse := func(u, v float64) Point {
+		return Point{
+			center.X + rx*u*math.Cos(phi) - ry*v*math.Sin(phi),
+			center.Y + rx*u*math.Sin(phi) + ry*v*math.Cos(phi),
+		}
+	}
+
+	cubics := make([]*Cubic, 0, count)
+	for i := 0; i < count; i++ {
+		a1 := startAngle + step*float64(i)
+		a2 := a1 + step
+		cubic := &Cubic{
+			From:     onEllipse(math.Cos(a1), math.Sin(a1)),
+			Control1: onEllipse(math.Cos(a1)-k*math.Sin(a1), math.Sin(a1)+k*math.Cos(a1)),
+			Control2: onEllipse(math.Cos(a2)+k*math.Sin(a2), math.Sin(a2)-k*math.Cos(a2)),
+			To:       onEllipse(math.Cos(a2), math.Sin(a2)),
+		}
+		cubics = append(cubics, cubic)
+	}
+	// Use the exact endpoints so the curves join their neighbours
+	cubics[0].From = from
+	cubics[count-1].To = to
+	return cubics
+}
@@ -0,0 +1,210 @@
+package svg
+
+import (
+	"bufio"
+	"encoding/xml"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Style is a CSS class applied to drawing elements
+type Style struct {
+	Class       string
+	Stroke      string
+	StrokeWidth float64
+	// DashArray is the stroke-dasharray of the class, for example "4 2". Empty for solid strokes.
+	DashArray string
+	Fill      string
+	FontSize  float64
+}
+
+// Element is an item of a [Drawing]: an [Outline], a [Circle] or a [Text]
+type Element interface {
+	write(w *elementWriter)
+}
+
+// Outline draws a path
+type Outline struct {
+	Class string
+	Path  Path
+}
+
+// Circle draws a circle
+type Circle struct {
+	Class  string
+	Center Point
+	Radius float64
+}
+
+// Text draws a label centered on At, rotated by Angle degrees clockwise as displayed
+type Text struct {
+	Class   string
+	At      Point
+	Content string
+	Angle   float64
+}
+
+// Drawing is an SVG document. ViewBox holds the minimum x, minimum y, width and height of the visible area in user units.
+type Drawing struct {
+	ViewBox  [4]float64
+	Styles   []Style
+	Elements []Element
+}
+
+// Write writes the drawing as an SVG document. Numbers are rounded to a fixed precision so
+// drawing the same geometry always produces the same document.
+func Write(writer io.Writer, drawing *Drawing) error {
+	w := &elementWriter{bufio.NewWriter(writer)}
+	w.w.WriteString(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="`)
+	w.numbers(drawing.ViewBox[:]...)
+	w.w.WriteString(`" width="`)
+	w.number(drawing.ViewBox[2])
+	w.w.WriteString(`" height="`)
+	w.number(drawing.ViewBox[3])
+	w.w.WriteString("\">\n")
+
+	if len(drawing.Styles) > 0 {
+		w.w.WriteString("<style>\n")
+		for _, style := range drawing.Styles {
+			w.style(style)
+		}
+		w.w.WriteString("</style>\n")
+	}
+	for _, element := range drawing.Elements {
+		element.write(w)
+	}
+	w.w.WriteString("</svg>\n")
+
+	return w.w.Flush()
+}
+
+type elementWriter struct {
+	w *bufio.Writer
+}
+
+// FormatNumber formats a number as it is written to SVG documents: rounded to four decimal places and never negative zero
+func FormatNumber(v float64) string {
+	v = math.Round(v*1e4) / 1e4
+	if v == 0 {
+		v = 0
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func (w *elementWriter) number(v float64) {
+	w.w.WriteString(FormatNumber(v))
+}
+
+func (w *elementWriter) numbers(values ...float64) {
+	for i, v := range values {
+		if i > 0 {
+			w.w.WriteByte(' ')
+		}
+		w.number(v)
+	}
+}
+
+func (w *elementWriter) attribute(name string, value string) {
+	w.w.WriteByte(' ')
+	w.w.WriteString(name)
+	w.w.WriteString(`="`)
+	xml.EscapeText(w.w, []byte(value))
+	w.w.WriteByte('"')
+}
+
+func (w *elementWriter) style(style Style) {
+	declarations := make([]string, 0, 5)
+	fill := style.Fill
+	if fill == "" {
+		fill = "none"
+	}
+	declarations = append(declarations, "fill:"+fill)
+	if style.Stroke != "" {
+		declarations = append(declarations, "stroke:"+style.Stroke)
+	}
+	if style.StrokeWidth > 0 {
+		declarations = append(declarations, "stroke-width:"+FormatNumber(style.StrokeWidth))
+	}
+	if style.DashArray != "" {
+		declarations = append(declarations, "stroke-dasharray:"+style.DashArray)
+	}
+	if style.FontSize > 0 {
+		declarations = append(declarations, "font-size:"+FormatNumber(style.FontSize)+"px", "font-family:sans-serif")
+	}
+	w.w.WriteByte('.')
+	w.w.WriteString(style.Class)
+	w.w.WriteByte('{')
+	w.w.WriteString(strings.Join(declarations, ";"))
+	w.w.WriteString("}\n")
+}
+
+func (o *Outline) write(w *elementWriter) {
+	if len(o.Path.Segments) == 0 {
+		return
+	}
+	w.w.WriteString("<path")
+	w.attribute("class", o.Class)
+	w.w.WriteString(` d="M`)
+	w.numbers(o.Path.Segments[0].Start().X, o.Path.Segments[0].Start().Y)
+	for _, segment := range o.Path.Segments {
+		switch s := segment.(type) {
+		case *Line:
+			w.w.WriteString(" L")
+			w.numbers(s.To.X, s.To.Y)
+		case *Arc:
+			w.w.WriteString(" A")
+			largeArc, sweep := 0.0, 0.0
+			if s.isLarge() {
+				largeArc = 1
+			}
+			if s.Sweep {
+				sweep = 1
+			}
+			w.numbers(s.Radius, s.Radius, 0, largeArc, sweep, s.To.X, s.To.Y)
+		case *Cubic:
+			w.w.WriteString(" C")
+			w.numbers(s.Control1.X, s.Control1.Y, s.Control2.X, s.Control2.Y, s.To.X, s.To.Y)
+		}
+	}
+	if o.Path.Closed {
+		w.w.WriteString(" Z")
+	}
+	w.w.WriteString("\"/>\n")
+}
+
+// isLarge returns whether the arc turns more than half way around its center
+func (a *Arc) isLarge() bool {
+	startAngle := math.Atan2(a.From.Y-a.Center.Y, a.From.X-a.Center.X)
+	endAngle := math.Atan2(a.To.Y-a.Center.Y, a.To.X-a.Center.X)
+	sweep := math.Mod(endAngle-startAngle+4*math.Pi, 2*math.Pi)
+	if !a.Sweep {
+		sweep = 2*math.Pi - sweep
+	}
+	return sweep > math.Pi
+}
+
+func (c *Circle) write(w *elementWriter) {
+	w.w.WriteString("<circle")
+	w.attribute("class", c.Class)
+	w.attribute("cx", FormatNumber(c.Center.X))
+	w.attribute("cy", FormatNumber(c.Center.Y))
+	w.attribute("r", FormatNumber(c.Radius))
+	w.w.WriteString("/>\n")
+}
+
+func (t *Text) write(w *elementWriter) {
+	w.w.WriteString("<text")
+	w.attribute("class", t.Class)
+	w.attribute("x", FormatNumber(t.At.X))
+	w.attribute("y", FormatNumber(t.At.Y))
+	w.attribute("text-anchor", "middle")
+	w.attribute("dominant-baseline", "middle")
+	if FormatNumber(t.Angle) != "0" {
+		w.attribute("transform", "rotate("+FormatNumber(t.Angle)+" "+FormatNumber(t.At.X)+" "+FormatNumber(t.At.Y)+")")
+	}
+	w.w.WriteByte('>')
+	xml.EscapeText(w.w, []byte(t.Content))
+	w.w.WriteString("</text>\n")
+}
@@ -0,0 +1,80 @@
+package svg
+
+import (
+	"bytes"
+	"testing"
+)
+
+// dimensionedDrawing returns a drawing styled like a sketch export: geometry, dashed construction lines and a labelled
+// dimension
+func dimensionedDrawing() *Drawing {
+	return &Drawing{
+		ViewBox: [4]float64{-1, -11, 12, 12},
+		Styles: []Style{
+			{Class: "geometry", Stroke: "black", StrokeWidth: 0.1},
+			{Class: "construction", Stroke: "gray", StrokeWidth: 0.05, DashArray: "0.5 0.25"},
+			{Class: "dimension", Stroke: "blue", StrokeWidth: 0.05},
+			{Class: "label", Fill: "blue", FontSize: 1},
+		},
+		Elements: []Element{
+			&Outline{Class: "geometry", Path: Path{Closed: true, Segments: []Segment{
+				&Line{From: Point{0, 0}, To: Point{10, 0}},
+				&Arc{From: Point{10, 0}, To: Point{10, -10}, Center: Point{10, -5}, Radius: 5, Sweep: false},
+				&Cubic{From: Point{10, -10}, Control1: Point{6.66666, -10}, Control2: Point{3.33333, -10}, To: Point{0, -10}},
+				&Line{From: Point{0, -10}, To: Point{0, 0}},
+			}}},
+			&Outline{Class: "construction", Path: Path{Segments: []Segment{&Line{From: Point{-1e-9, -5}, To: Point{15, -5}}}}},
+			&Circle{Class: "construction", Center: Point{10, -5}, Radius: 0.125},
+			&Outline{Class: "dimension", Path: Path{Segments: []Segment{&Line{From: Point{0, 0.5}, To: Point{10, 0.5}}}}},
+			&Text{Class: "label", At: Point{5, 1}, Content: "10"},
+			&Text{Class: "label", At: Point{-0.5, -5}, Content: "R5 <top & bottom>", Angle: -90},
+		},
+	}
+}
+
+const dimensionedDrawingSVG = `<svg xmlns="http://www.w3.org/2000/svg" viewBox="-1 -11 12 12" width="12" height="12">
+<style>
+.geometry{fill:none;stroke:black;stroke-width:0.1}
+.construction{fill:none;stroke:gray;stroke-width:0.05;stroke-dasharray:0.5 0.25}
+.dimension{fill:none;stroke:blue;stroke-width:0.05}
+.label{fill:blue;font-size:1px;font-family:sans-serif}
+</style>
+<path class="geometry" d="M0 0 L10 0 A5 5 0 0 0 10 -10 C6.6667 -10 3.3333 -10 0 -10 L0 0 Z"/>
+<path class="construction" d="M0 -5 L15 -5"/>
+<circle class="construction" cx="10" cy="-5" r="0.125"/>
+<path class="dimension" d="M0 0.5 L10 0.5"/>
+<text class="label" x="5" y="1" text-anchor="middle" dominant-baseline="middle">10</text>
+<text class="label" x="-0.5" y="-5" text-anchor="middle" dominant-baseline="middle" transform="rotate(-90 -0.5 -5)">R5 &lt;top &amp; bottom&gt;</text>
+</svg>
+`
+
+func TestWrite(t *testing.T) {
+	var first, second bytes.Buffer
+	if err := Write(&first, dimensionedDrawing()); err != nil {
+		t.Fatal(err)
+	}
+	if err := Write(&second, dimensionedDrawing()); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(first.Bytes(), second.Bytes()) {
+		t.Errorf("writing the same drawing twice differs:\n%s\n%s", first.String(), second.String())
+	}
+	if first.String() != dimensionedDrawingSVG {
+		t.Errorf("got\n%s\nwant\n%s", first.String(), dimensionedDrawingSVG)
+	}
+}
+
+func TestWriteRead(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := Write(&buffer, dimensionedDrawing()); err != nil {
+		t.Fatal(err)
+	}
+	paths, err := Read(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Three outlines and the construction circle; text is not geometry
+	if len(paths) != 4 {
+		t.Errorf("read %d paths, want 4: %s", len(paths), describe(paths))
+	}
+}